@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	ActionArchive = "archive"
+	ActionBrowse  = "browse"
+	ActionCopySSH = "copy-ssh"
+	ActionIssues  = "issues"
+	ActionOpen    = "open"
+	ActionStar    = "star"
+	ActionUnstar  = "unstar"
+)
+
+// PickerActions are the actions that can be bound to keys in the picker
+var PickerActions = []string{ActionArchive, ActionBrowse, ActionCopySSH, ActionIssues, ActionOpen, ActionStar, ActionUnstar}
+
+// reservedPickerKeys are used by the pickers themselves and cannot be bound to actions. Terminals send ctrl-h, ctrl-i,
+// ctrl-j and ctrl-m for backspace, tab and enter, so those are reserved too
+var reservedPickerKeys = []string{"ctrl-c", "ctrl-h", "ctrl-i", "ctrl-j", "ctrl-m", "ctrl-n", "ctrl-p", "ctrl-r", "ctrl-u", "ctrl-v", "enter", "esc", "tab"}
+
+// clipboardCommands are tried in order to copy text to the clipboard
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+	{"clip.exe"},
+}
+
+// pickerActions returns the configured key bindings of picker actions, none unless ui.actions is set
+func pickerActions() map[string]string {
+	return config.UI.Actions
+}
+
+// pickerActionKeys returns the keys bound to picker actions in sorted order
+func pickerActionKeys() []string {
+	keys := make([]string, 0, len(pickerActions()))
+	for key := range pickerActions() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RunPickerAction runs a picker action on the chosen repositories
+func RunPickerAction(action string, repos []Repo) error {
+	if len(repos) == 0 {
+		fmt.Println("No repositories selected.")
+		return nil
+	}
+
+	switch action {
+	case ActionBrowse:
+		return forEachRepo(repos, "open", func(repo Repo) error {
+			return runGhCommand("browse", "--repo", repo.FullName())
+		})
+	case ActionCopySSH:
+		return copySSHURLs(repos)
+	case ActionOpen:
+		return openExistingClones(repos)
+	case ActionStar, ActionUnstar:
+		return starRepos(repos, action == ActionStar)
+	case ActionArchive:
+		return archiveRepos(repos)
+	case ActionIssues:
+		return forEachRepo(repos, "list issues of", func(repo Repo) error {
+			fmt.Printf("%s Issues of %s\n", GetIcon("issue"), repo.FullName())
+			return runGhCommand("issue", "list", "--repo", repo.FullName())
+		})
+	default:
+		return fmt.Errorf("unknown picker action %q (supported: %s)", action, strings.Join(PickerActions, ", "))
+	}
+}
+
+// copySSHURLs copies the SSH clone URLs of the repositories to the clipboard, one per line
+func copySSHURLs(repos []Repo) error {
+	urls := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		urls = append(urls, ConvertToSSHURL(repo.HTMLURL))
+	}
+	text := strings.Join(urls, "\n")
+
+	for _, command := range clipboardCommands {
+		if !isCommandAvailable(command[0]) {
+			continue
+		}
+		cmd := ExecCommand(command[0], command[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to copy to the clipboard with %s: %w", command[0], err)
+		}
+		fmt.Printf("%s Copied %d SSH URLs to the clipboard\n", GetIcon("success"), len(urls))
+		return nil
+	}
+
+	fmt.Printf("%s No clipboard command found, SSH URLs:\n%s\n", GetIcon("info"), text)
+	return nil
+}
+
+// openExistingClones opens the repositories that are already cloned with tmux or the post-clone command
+func openExistingClones(repos []Repo) error {
+	var cloned []Repo
+	for _, repo := range repos {
+		if IsCloned(repo) {
+			cloned = append(cloned, repo)
+		} else {
+			fmt.Printf("%s %s is not cloned, skipping\n", GetIcon("info"), repo.FullName())
+		}
+	}
+	if len(cloned) == 0 {
+		return nil
+	}
+	return openRepos(cloned)
+}
+
+// starRepos stars or unstars the repositories for the current user
+func starRepos(repos []Repo, star bool) error {
+	verb, done, method := "star", "Starred", "PUT"
+	if !star {
+		verb, done, method = "unstar", "Unstarred", "DELETE"
+	}
+	return forEachRepo(repos, verb, func(repo Repo) error {
+		if DryRun {
+			fmt.Printf("Would %s %s\n", verb, repo.FullName())
+			return nil
+		}
+		if _, err := ghAPIOutput("user/starred/"+repo.FullName(), "--method", method); err != nil {
+			return err
+		}
+		fmt.Printf("%s %s %s\n", GetIcon("star"), done, repo.FullName())
+		return nil
+	})
+}
+
+// archiveRepos archives the repositories after confirmation
+func archiveRepos(repos []Repo) error {
+	names := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		names = append(names, repo.FullName())
+	}
+
+	if DryRun {
+		for _, name := range names {
+			fmt.Printf("Would archive %s\n", name)
+		}
+		return nil
+	}
+	if !Confirm(fmt.Sprintf("Archive %d repositories (%s)?", len(names), strings.Join(names, ", "))) {
+		fmt.Println("Archive cancelled.")
+		return nil
+	}
+
+	return forEachRepo(repos, "archive", func(repo Repo) error {
+		return runGhCommand("repo", "archive", repo.FullName(), "--yes")
+	})
+}
+
+// forEachRepo runs fn for every repository, reporting failures and continuing with the rest
+func forEachRepo(repos []Repo, verb string, fn func(Repo) error) error {
+	failed := 0
+	for _, repo := range repos {
+		if err := fn(repo); err != nil {
+			fmt.Printf("%s Failed to %s %s: %v\n", GetIcon("error"), verb, repo.FullName(), err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d of %d repositories", verb, failed, len(repos))
+	}
+	return nil
+}
+
+// runGhCommand runs a gh command with its output shown to the user
+func runGhCommand(args ...string) error {
+	cmd := ExecCommand("gh", args...)
+	if len(cmd.Env) == 0 {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, "GH_PROMPT_DISABLED=1")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// validatePickerActions checks that every key is bound to a supported action and leaves the picker's own keys alone
+func validatePickerActions(actions map[string]string) error {
+	for key, action := range actions {
+		if key == "" || strings.ContainsAny(key, ", :\t") {
+			return fmt.Errorf("invalid ui.actions key %q", key)
+		}
+		if slices.Contains(reservedPickerKeys, key) {
+			return fmt.Errorf("invalid ui.actions key %q: reserved by the picker (%s)", key, strings.Join(reservedPickerKeys, ", "))
+		}
+		if !slices.Contains(PickerActions, action) {
+			return fmt.Errorf("invalid ui.actions.%s: %q (supported: %s)", key, action, strings.Join(PickerActions, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const backupManifestName = "manifest.json"
+
+var (
+	backupUser   string
+	backupDest   string
+	backupBundle bool
+	backupWiki   bool
+)
+
+var BackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Create or update mirror clones of every repository for local backup",
+	Run: func(cmd *cobra.Command, args []string) {
+		opts := BackupOptions{Dest: backupDest, Bundle: backupBundle, Wiki: backupWiki}
+		if err := runBackup(cmd.Context(), backupUser, opts); err != nil {
+			fmt.Println(err)
+			os.Exit(ExitCodeFor(err))
+		}
+	},
+}
+
+func init() {
+	BackupCmd.Flags().StringVarP(&backupUser, "user", "u", "", "The user whose repositories to back up")
+	BackupCmd.Flags().StringVarP(&backupDest, "dest", "o", "", "Directory where mirrors, bundles and the manifest are written")
+	BackupCmd.Flags().BoolVar(&backupBundle, "bundle", false, "Also write a git bundle file for every repository")
+	BackupCmd.Flags().BoolVar(&backupWiki, "wiki", false, "Also mirror repository wikis")
+	_ = BackupCmd.MarkFlagRequired("dest")
+	rootCmd.AddCommand(BackupCmd)
+}
+
+// BackupOptions controls what a backup writes
+type BackupOptions struct {
+	Dest   string
+	Bundle bool
+	Wiki   bool
+}
+
+// BackupManifest records the contents of a backup directory
+type BackupManifest struct {
+	User         string        `json:"user"`
+	GeneratedAt  time.Time     `json:"generated_at"`
+	Repositories []BackupEntry `json:"repositories"`
+}
+
+// BackupEntry records the backup of a single repository
+type BackupEntry struct {
+	FullName   string       `json:"full_name"`
+	URL        string       `json:"url"`
+	Status     ResultStatus `json:"status"`
+	Mirror     string       `json:"mirror"`
+	MirrorSize int64        `json:"mirror_size"`
+	Bundle     string       `json:"bundle,omitempty"`
+	BundleSize int64        `json:"bundle_size,omitempty"`
+	Wiki       string       `json:"wiki,omitempty"`
+	BackedUpAt time.Time    `json:"backed_up_at"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// runBackup mirrors every repository of a user and writes the manifest
+func runBackup(parent context.Context, user string, opts BackupOptions) error {
+	dest, err := expandPath(opts.Dest)
+	if err != nil {
+		return fmt.Errorf("invalid backup destination: %w", err)
+	}
+	opts.Dest = dest
+
+	// a backup must not miss repositories created since the cache was last refreshed
+	oldRefresh := RefreshCache
+	RefreshCache = true
+	defer func() { RefreshCache = oldRefresh }()
+
+	repos, err := GetRepos(user)
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		fmt.Println("No repositories found to back up.")
+		return nil
+	}
+
+	ctx, cancel := NewCloneContext(parent, len(repos))
+	defer cancel()
+
+	results := BackupRepos(ctx, repos, opts)
+	PrintResultSummary(os.Stdout, results)
+
+	manifestPath, err := WriteBackupManifest(opts, user, results)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Manifest written to %s\n", GetIcon("info"), manifestPath)
+
+	if parent.Err() != nil {
+		PrintInterruptedSummary(os.Stdout, results)
+		return &ExitError{Code: ExitCodeInterrupted, Err: fmt.Errorf("backup interrupted: %w", parent.Err())}
+	}
+
+	if code := results.ExitCode(); code != 0 {
+		return &ExitError{Code: code, Err: fmt.Errorf("error during backup: %w", results.Err())}
+	}
+	return nil
+}
+
+// BackupRepos creates or updates mirror clones concurrently
+func BackupRepos(ctx context.Context, repos []Repo, opts BackupOptions) RepoResults {
+	if len(repos) == 0 {
+		return nil
+	}
+
+	maxConcurrent := getMaxConcurrentClones()
+	fmt.Printf("Backing up %d repositories to %s with up to %d concurrent operations...\n", len(repos), opts.Dest, maxConcurrent)
+
+	return runConcurrently(ctx, repos, maxConcurrent, func(ctx context.Context, index int, repo Repo) RepoResult {
+		return backupSingleRepo(ctx, index, repo, len(repos), opts)
+	})
+}
+
+// backupSingleRepo mirrors one repository, and optionally its wiki and a bundle
+func backupSingleRepo(ctx context.Context, index int, repo Repo, totalRepos int, opts BackupOptions) RepoResult {
+	prefix := fmt.Sprintf("[%d/%d]", index+1, totalRepos)
+	mirrorPath := backupMirrorPath(opts.Dest, repo, "")
+	cloneURL := CloneURL(repo.HTMLURL, ResolveCloneOptions(repo).Protocol)
+
+	status, err := updateMirror(ctx, repo.FullName(), cloneURL, mirrorPath)
+	if err != nil {
+		if ctx.Err() != nil {
+			status = StatusCancelled
+		}
+		printCloneStatus(fmt.Sprintf("%s %s %v\n", prefix, GetIcon("error"), err))
+		return RepoResult{Repo: repo, Path: mirrorPath, Status: status, Err: err}
+	}
+
+	if opts.Wiki {
+		wikiURL := strings.TrimSuffix(cloneURL, ".git") + ".wiki.git"
+		if _, err := updateMirror(ctx, repo.FullName()+" wiki", wikiURL, backupMirrorPath(opts.Dest, repo, ".wiki")); err != nil {
+			printCloneStatus(fmt.Sprintf("%s %s No wiki backed up for %s: %v\n", prefix, GetIcon("info"), repo.FullName(), err))
+		}
+	}
+
+	if opts.Bundle {
+		bundlePath := backupBundlePath(opts.Dest, repo)
+		if err := createBundle(ctx, mirrorPath, bundlePath); err != nil {
+			err = fmt.Errorf("failed to bundle %s: %w", repo.FullName(), err)
+			printCloneStatus(fmt.Sprintf("%s %s %v\n", prefix, GetIcon("error"), err))
+			return RepoResult{Repo: repo, Path: mirrorPath, Status: StatusFailed, Err: err}
+		}
+	}
+
+	printCloneStatus(fmt.Sprintf("%s %s Backed up %s to %s\n", prefix, GetIcon("success"), repo.FullName(), mirrorPath))
+	return RepoResult{Repo: repo, Path: mirrorPath, Status: status}
+}
+
+// updateMirror fetches into an existing mirror or creates a new one, retrying on failure
+func updateMirror(ctx context.Context, name, cloneURL, mirrorPath string) (ResultStatus, error) {
+	if isBareRepo(mirrorPath) {
+		err := runWithRetry(ctx, name, func() error {
+			_, err := runGit(ctx, mirrorPath, "remote", "update", "--prune")
+			return err
+		})
+		if err != nil {
+			return StatusFailed, fmt.Errorf("failed to update mirror of %s: %w", name, err)
+		}
+		return StatusUpdated, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(mirrorPath), 0o750); err != nil {
+		return StatusFailed, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	// only clean up after a failed clone when the directory did not exist before
+	created := !fileExists(mirrorPath)
+	err := runWithRetry(ctx, name, func() error {
+		_, err := runGit(ctx, filepath.Dir(mirrorPath), "clone", "--mirror", cloneURL, mirrorPath)
+		if err != nil && created {
+			removePartialClone(mirrorPath)
+		}
+		return err
+	})
+	if err != nil {
+		return StatusFailed, fmt.Errorf("failed to mirror %s: %w", name, err)
+	}
+	return StatusCloned, nil
+}
+
+// createBundle writes a bundle containing every ref of a mirror
+func createBundle(ctx context.Context, mirrorPath, bundlePath string) error {
+	if err := os.MkdirAll(filepath.Dir(bundlePath), 0o750); err != nil {
+		return fmt.Errorf("failed to create bundle directory: %w", err)
+	}
+	_, err := runGit(ctx, mirrorPath, "bundle", "create", bundlePath, "--all")
+	return err
+}
+
+// WriteBackupManifest writes manifest.json describing every backed up repository and returns its path
+func WriteBackupManifest(opts BackupOptions, user string, results RepoResults) (string, error) {
+	now := time.Now().UTC()
+	manifest := BackupManifest{User: user, GeneratedAt: now}
+	manifestPath := filepath.Join(opts.Dest, backupManifestName)
+	previous := readBackupTimes(manifestPath)
+
+	for _, result := range results {
+		entry := BackupEntry{
+			FullName:   result.Repo.FullName(),
+			URL:        result.Repo.HTMLURL,
+			Status:     result.Status,
+			Mirror:     result.Path,
+			MirrorSize: dirSize(result.Path),
+			BackedUpAt: now,
+		}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+			entry.BackedUpAt = previous[entry.FullName]
+		}
+		// a bundle left by an earlier run is stale when this run failed
+		if bundlePath := backupBundlePath(opts.Dest, result.Repo); opts.Bundle && result.Err == nil && fileExists(bundlePath) {
+			entry.Bundle = bundlePath
+			entry.BundleSize = dirSize(bundlePath)
+		}
+		if wikiPath := backupMirrorPath(opts.Dest, result.Repo, ".wiki"); opts.Wiki && isBareRepo(wikiPath) {
+			entry.Wiki = wikiPath
+		}
+		manifest.Repositories = append(manifest.Repositories, entry)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal backup manifest: %w", err)
+	}
+
+	if err := os.MkdirAll(opts.Dest, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	if err := atomicWriteFile(manifestPath, data); err != nil {
+		return "", fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+	return manifestPath, nil
+}
+
+// readBackupTimes returns the last successful backup time of every repository in an existing manifest
+func readBackupTimes(manifestPath string) map[string]time.Time {
+	times := make(map[string]time.Time)
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return times
+	}
+
+	var manifest BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return times
+	}
+	for _, entry := range manifest.Repositories {
+		times[entry.FullName] = entry.BackedUpAt
+	}
+	return times
+}
+
+// backupMirrorPath returns dest/owner/name<suffix>.git
+func backupMirrorPath(dest string, repo Repo, suffix string) string {
+	return filepath.Join(dest, repo.Owner.Login, repo.Name+suffix+".git")
+}
+
+// backupBundlePath returns dest/bundles/owner/name.bundle
+func backupBundlePath(dest string, repo Repo) string {
+	return filepath.Join(dest, "bundles", repo.Owner.Login, repo.Name+".bundle")
+}
+
+// isBareRepo reports whether path looks like a bare git repository
+func isBareRepo(path string) bool {
+	return fileExists(filepath.Join(path, "HEAD")) && fileExists(filepath.Join(path, "objects"))
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// dirSize returns the total size in bytes of the files below path
+func dirSize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && !d.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Ecosystems whose dependencies the bootstrap stage knows how to install
+const (
+	EcosystemGo      = "go"
+	EcosystemNpm     = "npm"
+	EcosystemPnpm    = "pnpm"
+	EcosystemYarn    = "yarn"
+	EcosystemBun     = "bun"
+	EcosystemCargo   = "cargo"
+	EcosystemUv      = "uv"
+	EcosystemPoetry  = "poetry"
+	EcosystemPip     = "pip"
+	EcosystemBundler = "bundler"
+)
+
+// DefaultBootstrapCommands are the install commands used when bootstrap.commands does not override an ecosystem
+var DefaultBootstrapCommands = map[string][]string{
+	EcosystemGo:      {"go", "mod", "download"},
+	EcosystemNpm:     {"npm", "install"},
+	EcosystemPnpm:    {"pnpm", "install", "--frozen-lockfile"},
+	EcosystemYarn:    {"yarn", "install", "--frozen-lockfile"},
+	EcosystemBun:     {"bun", "install", "--frozen-lockfile"},
+	EcosystemCargo:   {"cargo", "fetch"},
+	EcosystemUv:      {"uv", "sync"},
+	EcosystemPoetry:  {"poetry", "install"},
+	EcosystemPip:     {"pip", "install", "-e", "."},
+	EcosystemBundler: {"bundle", "install"},
+}
+
+// BootstrapPlan lists the install commands to run in a freshly cloned repository
+type BootstrapPlan struct {
+	Repo     Repo
+	Path     string
+	Commands [][]string
+}
+
+// BootstrapResult is the captured outcome of bootstrapping one repository
+type BootstrapResult struct {
+	Plan   BootstrapPlan
+	Output string
+	Err    error
+}
+
+// DetectEcosystems inspects the files of a clone and returns the ecosystems it uses
+func DetectEcosystems(path string) []string {
+	has := func(name string) bool { return fileExists(filepath.Join(path, name)) }
+
+	var ecosystems []string
+	if has("go.mod") {
+		ecosystems = append(ecosystems, EcosystemGo)
+	}
+	if has("package.json") {
+		switch {
+		case has("pnpm-lock.yaml"):
+			ecosystems = append(ecosystems, EcosystemPnpm)
+		case has("yarn.lock"):
+			ecosystems = append(ecosystems, EcosystemYarn)
+		case has("bun.lockb"), has("bun.lock"):
+			ecosystems = append(ecosystems, EcosystemBun)
+		default:
+			ecosystems = append(ecosystems, EcosystemNpm)
+		}
+	}
+	if has("Cargo.toml") {
+		ecosystems = append(ecosystems, EcosystemCargo)
+	}
+	if has("pyproject.toml") {
+		switch {
+		case has("uv.lock"):
+			ecosystems = append(ecosystems, EcosystemUv)
+		case has("poetry.lock"):
+			ecosystems = append(ecosystems, EcosystemPoetry)
+		default:
+			ecosystems = append(ecosystems, EcosystemPip)
+		}
+	}
+	if has("Gemfile") {
+		ecosystems = append(ecosystems, EcosystemBundler)
+	}
+	return ecosystems
+}
+
+// bootstrapCommand returns the configured or default install command for an ecosystem
+func bootstrapCommand(ecosystem string) []string {
+	if command, ok := config.Integrations.Bootstrap.Commands[ecosystem]; ok {
+		return command
+	}
+	return DefaultBootstrapCommands[ecosystem]
+}
+
+// PlanBootstrap detects the install commands for every repository that has any
+func PlanBootstrap(repos []Repo) []BootstrapPlan {
+	var plans []BootstrapPlan
+	for _, repo := range repos {
+		path, err := GetRepoPath(repo)
+		if err != nil {
+			continue
+		}
+
+		plan := BootstrapPlan{Repo: repo, Path: path}
+		for _, ecosystem := range DetectEcosystems(path) {
+			if command := bootstrapCommand(ecosystem); len(command) > 0 {
+				plan.Commands = append(plan.Commands, command)
+			}
+		}
+		if len(plan.Commands) > 0 {
+			plans = append(plans, plan)
+		}
+	}
+	return plans
+}
+
+// RunBootstrap installs dependencies of newly cloned repositories that are allowlisted or confirmed; dry runs
+// skip it because the files that decide what to install are not cloned yet
+func RunBootstrap(ctx context.Context, repos []Repo) []BootstrapResult {
+	if !config.Integrations.Bootstrap.Enabled || DryRun || len(repos) == 0 {
+		return nil
+	}
+
+	plans := approveBootstrap(PlanBootstrap(repos))
+	if len(plans) == 0 {
+		return nil
+	}
+
+	fmt.Printf("Bootstrapping %d repositories...\n", len(plans))
+	results := make([]BootstrapResult, len(plans))
+	sem := make(chan struct{}, getMaxConcurrentClones())
+	var wg sync.WaitGroup
+
+	for i, plan := range plans {
+		wg.Add(1)
+		go func(i int, plan BootstrapPlan) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = bootstrapRepo(ctx, plan)
+			printBootstrapResult(results[i])
+		}(i, plan)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// approveBootstrap keeps plans that are allowlisted, asking once for confirmation of the rest when enabled
+func approveBootstrap(plans []BootstrapPlan) []BootstrapPlan {
+	var approved, pending []BootstrapPlan
+	for _, plan := range plans {
+		if isBootstrapAllowed(plan.Repo) {
+			approved = append(approved, plan)
+		} else {
+			pending = append(pending, plan)
+		}
+	}
+
+	if len(pending) == 0 || !config.Integrations.Bootstrap.Confirm {
+		return approved
+	}
+
+	fmt.Printf("%s The following dependency installs will run:\n", GetIcon("info"))
+	for _, plan := range pending {
+		for _, command := range plan.Commands {
+			fmt.Printf("      %s: %s\n", plan.Repo.FullName(), strings.Join(command, " "))
+		}
+	}
+	if Confirm("Install dependencies?") {
+		approved = append(approved, pending...)
+	}
+	return approved
+}
+
+// isBootstrapAllowed reports whether a repository matches the bootstrap allowlist
+func isBootstrapAllowed(repo Repo) bool {
+	for _, pattern := range config.Integrations.Bootstrap.Allow {
+		if matchRepoPattern(pattern, repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// bootstrapRepo runs the install commands of a plan in order, capturing their combined output
+func bootstrapRepo(ctx context.Context, plan BootstrapPlan) BootstrapResult {
+	var output bytes.Buffer
+	for _, command := range plan.Commands {
+		fmt.Fprintf(&output, "$ %s\n", strings.Join(command, " "))
+
+		cmd := ExecCommand(command[0], command[1:]...)
+		cmd.Dir = plan.Path
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+
+		if err := runCommandContext(ctx, cmd); err != nil {
+			return BootstrapResult{Plan: plan, Output: output.String(), Err: fmt.Errorf("%s failed: %w", command[0], err)}
+		}
+	}
+	return BootstrapResult{Plan: plan, Output: output.String()}
+}
+
+// printBootstrapResult prints the captured output of one repository as a single block
+func printBootstrapResult(result BootstrapResult) {
+	icon, outcome := GetIcon("success"), "bootstrapped"
+	if result.Err != nil {
+		icon, outcome = GetIcon("error"), fmt.Sprintf("bootstrap failed: %v", result.Err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %s\n", icon, result.Plan.Repo.Name, outcome)
+	for _, line := range strings.Split(strings.TrimRight(result.Output, "\n"), "\n") {
+		fmt.Fprintf(&b, "    %s\n", line)
+	}
+	printCloneStatus(b.String())
+}
+
+// validateBootstrap checks that command overrides name known ecosystems and are not empty
+func validateBootstrap(bootstrap BootstrapConfig) error {
+	known := make([]string, 0, len(DefaultBootstrapCommands))
+	for ecosystem := range DefaultBootstrapCommands {
+		known = append(known, ecosystem)
+	}
+	sort.Strings(known)
+
+	for ecosystem, command := range bootstrap.Commands {
+		if _, ok := DefaultBootstrapCommands[ecosystem]; !ok {
+			return fmt.Errorf("unknown integrations.bootstrap.commands ecosystem %q (supported: %s)", ecosystem, strings.Join(known, ", "))
+		}
+		if len(command) == 0 || strings.TrimSpace(command[0]) == "" {
+			return fmt.Errorf("integrations.bootstrap.commands.%s must not be empty", ecosystem)
+		}
+	}
+	return nil
+}
@@ -239,3 +239,59 @@ func getReadmeCachePath(user, repoName string) (string, error) {
 	filename := fmt.Sprintf("%s_%s.md", user, repoName)
 	return filepath.Join(cacheDir, "readmes", filename), nil
 }
+
+// forkDivergenceCache is the cached parent and divergence of a fork
+type forkDivergenceCache struct {
+	Parent     ForkParent     `json:"parent"`
+	Divergence ForkDivergence `json:"divergence"`
+}
+
+func LoadForkDivergenceFromCache(user, repoName string) (ForkParent, ForkDivergence, error) {
+	filePath, err := getForkDivergenceCachePath(user, repoName)
+	if err != nil {
+		return ForkParent{}, ForkDivergence{}, err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return ForkParent{}, ForkDivergence{}, err
+	}
+
+	var cached forkDivergenceCache
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return ForkParent{}, ForkDivergence{}, fmt.Errorf("failed to parse cached fork divergence: %w", err)
+	}
+	return cached.Parent, cached.Divergence, nil
+}
+
+func SaveForkDivergenceToCache(user, repoName string, parent ForkParent, divergence ForkDivergence) error {
+	filePath, err := getForkDivergenceCachePath(user, repoName)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(forkDivergenceCache{Parent: parent, Divergence: divergence})
+	if err != nil {
+		return fmt.Errorf("failed to marshal fork divergence: %w", err)
+	}
+	if err := atomicWriteFile(filePath, data); err != nil {
+		return fmt.Errorf("failed to write fork divergence cache: %w", err)
+	}
+
+	return nil
+}
+
+func getForkDivergenceCachePath(user, repoName string) (string, error) {
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	forksDir := filepath.Join(cacheDir, "forks")
+	if err := os.MkdirAll(forksDir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create fork cache directory: %w", err)
+	}
+
+	filename := fmt.Sprintf("%s_%s.json", user, repoName)
+	return filepath.Join(forksDir, filename), nil
+}
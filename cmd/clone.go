@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -24,33 +25,79 @@ func ConvertToSSHURL(httpsURL string) string {
 
 // CloneRepos clones repositories with default timeout and concurrency
 func CloneRepos(repos []Repo) error {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(CloneTimeoutMinutes)*time.Minute*time.Duration(len(repos)))
+	ctx, cancel := NewCloneContext(context.Background(), len(repos))
 	defer cancel()
 	return CloneReposWithContext(ctx, repos)
 }
 
+// NewCloneContext derives a context whose timeout scales with the number of repositories
+func NewCloneContext(parent context.Context, repoCount int) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, time.Duration(CloneTimeoutMinutes)*time.Minute*time.Duration(repoCount))
+}
+
 // CloneReposWithContext clones repositories concurrently with context support for cancellation
 func CloneReposWithContext(ctx context.Context, repos []Repo) error {
+	return CloneReposWithResults(ctx, repos).Err()
+}
+
+// CloneReposWithResults clones repositories concurrently and reports the outcome for every repository
+func CloneReposWithResults(ctx context.Context, repos []Repo) RepoResults {
 	if len(repos) == 0 {
 		return nil
 	}
 
+	if err := preflightDiskSpace(repos); err != nil {
+		fmt.Printf("%s %v\n", GetIcon("error"), err)
+		results := make(RepoResults, len(repos))
+		for i, repo := range repos {
+			results[i] = RepoResult{Repo: repo, Status: StatusFailed, Err: err}
+		}
+		return results
+	}
+
+	size := ""
+	if total := EstimateCloneSize(repos); total > 0 {
+		size = fmt.Sprintf(" (about %s)", FormatSize(total))
+	}
+
+	if DryRun {
+		fmt.Printf("Dry run: planning %d repositories%s, nothing will be changed...\n", len(repos), size)
+		return runConcurrently(ctx, repos, 1, func(ctx context.Context, index int, repo Repo) RepoResult {
+			return planSingleRepo(ctx, index, repo, len(repos))
+		})
+	}
+
 	maxConcurrent := getMaxConcurrentClones()
-	fmt.Printf("Cloning %d repositories with up to %d concurrent operations...\n", len(repos), maxConcurrent)
+	fmt.Printf("Cloning %d repositories%s with up to %d concurrent operations...\n", len(repos), size, maxConcurrent)
 
+	return runConcurrently(ctx, repos, maxConcurrent, func(ctx context.Context, index int, repo Repo) RepoResult {
+		return cloneSingleRepo(ctx, index, repo, len(repos))
+	})
+}
+
+// runConcurrently runs fn for every repository with at most maxConcurrent operations in flight
+func runConcurrently(ctx context.Context, repos []Repo, maxConcurrent int, fn func(context.Context, int, Repo) RepoResult) RepoResults {
 	sem := make(chan struct{}, maxConcurrent)
-	errChan := make(chan error, len(repos))
+	results := make(RepoResults, len(repos))
 	var wg sync.WaitGroup
 
 	for i, repo := range repos {
 		wg.Add(1)
 		go func(i int, repo Repo) {
 			defer wg.Done()
-			cloneSingleRepo(ctx, i, repo, len(repos), sem, errChan)
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i] = RepoResult{Repo: repo, Status: StatusCancelled, Err: fmt.Errorf("%s cancelled: %w", repo.Name, ctx.Err())}
+				return
+			}
+			defer func() { <-sem }()
+			results[i] = fn(ctx, i, repo)
 		}(i, repo)
 	}
 
-	return waitForCompletion(&wg, errChan)
+	wg.Wait()
+	return results
 }
 
 // getMaxConcurrentClones returns maximum concurrent clone operations
@@ -63,133 +110,247 @@ func getMaxConcurrentClones() int {
 }
 
 // cloneSingleRepo handles cloning of a single repository
-func cloneSingleRepo(ctx context.Context, index int, repo Repo, totalRepos int, sem chan struct{}, errChan chan error) {
-	select {
-	case sem <- struct{}{}:
-	case <-ctx.Done():
-		errChan <- fmt.Errorf("clone of %s cancelled: %w", repo.Name, ctx.Err())
-		return
+func cloneSingleRepo(ctx context.Context, index int, repo Repo, totalRepos int) RepoResult {
+	targetPath, exists, err := prepareTargetDirectory(repo)
+	if err != nil {
+		return RepoResult{Repo: repo, Status: StatusFailed, Err: err}
+	}
+	if exists {
+		return handleExistingClone(ctx, repo, targetPath, index, totalRepos)
+	}
+
+	if err := executeGitClone(ctx, repo, targetPath, index, totalRepos); err != nil {
+		status := StatusFailed
+		if ctx.Err() != nil {
+			status = StatusCancelled
+		}
+		return RepoResult{Repo: repo, Path: targetPath, Status: status, Err: err}
+	}
+
+	if _, err := applyIdentity(ctx, repo.Owner.Login, targetPath); err != nil {
+		printCloneStatus(fmt.Sprintf("[%d/%d] %s Could not apply git identity to %s: %v\n", index+1, totalRepos, GetIcon("error"), repo.Name, err))
+	}
+
+	if err := setupForkUpstream(ctx, repo, targetPath); err != nil {
+		printCloneStatus(fmt.Sprintf("[%d/%d] %s Could not add upstream remote for %s: %v\n", index+1, totalRepos, GetIcon("error"), repo.Name, err))
 	}
-	defer func() { <-sem }()
 
-	targetPath, err := prepareTargetDirectory(repo, index, totalRepos)
+	return RepoResult{Repo: repo, Path: targetPath, Status: StatusCloned}
+}
+
+// setupForkUpstream adds the parent of a forked repository as a remote and optionally tracks it
+func setupForkUpstream(ctx context.Context, repo Repo, targetPath string) error {
+	forkConfig := config.Integrations.Git.Forks
+	if !repo.IsFork || !forkConfig.AddUpstream {
+		return nil
+	}
+
+	info, err := GetForkInfo(repo.FullName())
 	if err != nil {
-		errChan <- err
-		return
+		return err
+	}
+
+	remoteName := forkConfig.RemoteName
+	if remoteName == "" {
+		remoteName = "upstream"
+	}
+	parentURL := CloneURL(info.Parent.HTMLURL, ResolveCloneOptions(repo).Protocol)
+	if _, err := runGit(ctx, targetPath, "remote", "add", remoteName, parentURL); err != nil {
+		return err
+	}
+
+	if !forkConfig.TrackUpstream {
+		return nil
+	}
+
+	if _, err := runGit(ctx, targetPath, "fetch", remoteName, info.Parent.DefaultBranch); err != nil {
+		return err
+	}
+	upstreamRef := remoteName + "/" + info.Parent.DefaultBranch
+	_, err = runGit(ctx, targetPath, "branch", "--set-upstream-to="+upstreamRef, info.DefaultBranch)
+	return err
+}
+
+// handleExistingClone verifies that an existing target directory is a clone of repo and applies the on_existing policy
+func handleExistingClone(ctx context.Context, repo Repo, targetPath string, index, totalRepos int) RepoResult {
+	prefix := fmt.Sprintf("[%d/%d]", index+1, totalRepos)
+	mode := getOnExistingMode()
+
+	if mode == OnExistingError {
+		err := fmt.Errorf("%s already exists in %s", repo.Name, targetPath)
+		printCloneStatus(fmt.Sprintf("%s %s %v\n", prefix, GetIcon("error"), err))
+		return RepoResult{Repo: repo, Path: targetPath, Status: StatusFailed, Err: err}
+	}
+
+	origin, err := getOriginURL(ctx, targetPath)
+	if err != nil || !RemoteMatchesRepo(origin, repo) {
+		reason := fmt.Errorf("%s exists but is not a clone of %s", targetPath, repo.HTMLURL)
+		if err == nil {
+			reason = fmt.Errorf("%s exists but its origin %s does not match %s", targetPath, origin, repo.HTMLURL)
+		}
+		printCloneStatus(fmt.Sprintf("%s %s %v\n", prefix, GetIcon("error"), reason))
+		return RepoResult{Repo: repo, Path: targetPath, Status: StatusMismatch, Err: reason}
+	}
+
+	switch mode {
+	case OnExistingFetch, OnExistingPull:
+		return refreshExistingClone(ctx, repo, targetPath, prefix, mode == OnExistingPull)
+	default:
+		printCloneStatus(fmt.Sprintf("%s %s %s already exists in %s, skipping clone\n", prefix, GetIcon("info"), repo.Name, targetPath))
+		return RepoResult{Repo: repo, Path: targetPath, Status: StatusSkipped}
 	}
-	if targetPath == "" {
-		return
+}
+
+// refreshExistingClone fetches an existing clone and, when pull is set, fast-forwards it unless it has
+// uncommitted changes
+func refreshExistingClone(ctx context.Context, repo Repo, targetPath, prefix string, pull bool) RepoResult {
+	failure := func(err error) RepoResult {
+		err = fmt.Errorf("failed to update %s: %w", repo.Name, err)
+		status := StatusFailed
+		if ctx.Err() != nil {
+			status = StatusCancelled
+		}
+		return RepoResult{Repo: repo, Path: targetPath, Status: status, Err: err}
+	}
+
+	if pull {
+		dirty, err := isWorkingTreeDirty(ctx, targetPath)
+		if err != nil {
+			return failure(err)
+		}
+		if dirty {
+			printCloneStatus(fmt.Sprintf("%s %s %s has uncommitted changes, skipping pull\n", prefix, GetIcon("info"), repo.Name))
+			return RepoResult{Repo: repo, Path: targetPath, Status: StatusDirty}
+		}
 	}
 
-	err = executeGitClone(ctx, repo, targetPath, index, totalRepos)
-	errChan <- err
+	if _, err := updateExistingClone(ctx, targetPath, pull); err != nil {
+		return failure(err)
+	}
+	printCloneStatus(fmt.Sprintf("%s %s Updated existing clone of %s in %s\n", prefix, GetIcon("success"), repo.Name, targetPath))
+	return RepoResult{Repo: repo, Path: targetPath, Status: StatusUpdated}
+}
+
+// updateExistingClone fetches origin and, when pull is set, fast-forwards the default branch,
+// reporting whether the default branch moved
+func updateExistingClone(ctx context.Context, path string, pull bool) (bool, error) {
+	if _, err := runGit(ctx, path, "fetch", "--prune", "origin"); err != nil {
+		return false, err
+	}
+	if !pull {
+		return false, nil
+	}
+
+	return fastForwardDefaultBranch(ctx, path)
+}
+
+// getOnExistingMode returns the configured policy for clone targets that already exist
+func getOnExistingMode() string {
+	mode := strings.ToLower(config.Integrations.Git.OnExisting)
+	if mode == "" {
+		return OnExistingSkip
+	}
+	return mode
 }
 
 var printMu sync.Mutex
 
+var retryBackoff = time.Second
+
 func printCloneStatus(msg string) {
 	printMu.Lock()
 	defer printMu.Unlock()
 	fmt.Print(msg)
 }
 
-// prepareTargetDirectory prepares the target directory for cloning
-func prepareTargetDirectory(repo Repo, index, totalRepos int) (string, error) {
-	targetDir, err := GetProjectsDirForUser(repo.Owner.Login)
+// prepareTargetDirectory prepares the target directory for cloning and reports whether it already exists
+func prepareTargetDirectory(repo Repo) (string, bool, error) {
+	targetPath, err := GetRepoPath(repo)
 	if err != nil {
-		return "", fmt.Errorf("failed to get target directory: %w", err)
+		return "", false, fmt.Errorf("failed to get target directory: %w", err)
 	}
 
-	if err := os.MkdirAll(targetDir, 0o750); err != nil {
-		return "", fmt.Errorf("failed to create target directory: %w", err)
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0o750); err != nil {
+		return "", false, fmt.Errorf("failed to create target directory: %w", err)
 	}
 
-	targetPath := filepath.Join(targetDir, repo.Name)
 	if _, err := os.Stat(targetPath); err == nil {
-		printCloneStatus(fmt.Sprintf("[%d/%d] %s %s already exists in %s, skipping clone\n", index+1, totalRepos, GetIcon("info"), repo.Name, targetPath))
-		return "", nil
+		return targetPath, true, nil
 	}
 
-	return targetPath, nil
+	return targetPath, false, nil
 }
 
 // executeGitClone executes the actual git clone command
 func executeGitClone(ctx context.Context, repo Repo, targetPath string, index, totalRepos int) error {
-	sshURL := ConvertToSSHURL(repo.HTMLURL)
-	args := BuildGitCloneArgs(sshURL, targetPath)
-	cmd := ExecCommand("git", args...)
+	opts := ResolveCloneOptions(repo)
+	cloneURL := CloneURL(repo.HTMLURL, opts.Protocol)
 
 	printCloneStatus(fmt.Sprintf("[%d/%d] %s Cloning %s to %s\n", index+1, totalRepos, GetIcon("cloning"), repo.Name, targetPath))
 
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start clone for %s: %w", repo.Name, err)
-	}
+	err := runWithRetry(ctx, repo.Name, func() error {
+		args := append(IdentityCloneArgs(repo.Owner.Login), opts.GitArgs(cloneURL, targetPath)...)
+		cmd := ExecCommand("git", args...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
 
-	done := make(chan struct{})
-	defer close(done)
-
-	go func() {
-		select {
-		case <-done:
-		case <-ctx.Done():
-			if cmd.Process != nil {
-				if killErr := cmd.Process.Kill(); killErr != nil {
-					fmt.Fprintf(os.Stderr, "Warning: Failed to kill process: %v\n", killErr)
-				}
-			}
+		if err := runCommandContext(ctx, cmd); err != nil {
+			removePartialClone(targetPath)
+			return handleCloneError(ctx, err, repo.Name, stderr.String())
 		}
-	}()
-
-	err := cmd.Wait()
+		return nil
+	})
 	if err != nil {
-		return handleCloneError(ctx, err, repo.Name)
+		return err
+	}
+
+	if len(opts.SparsePaths) > 0 {
+		args := append([]string{"sparse-checkout", "set"}, opts.SparsePaths...)
+		if _, err := runGit(ctx, targetPath, args...); err != nil {
+			printCloneStatus(fmt.Sprintf("[%d/%d] %s Could not set sparse checkout paths for %s: %v\n", index+1, totalRepos, GetIcon("error"), repo.Name, err))
+		}
 	}
 
 	printCloneStatus(fmt.Sprintf("[%d/%d] %s Successfully cloned %s to %s\n", index+1, totalRepos, GetIcon("success"), repo.Name, targetPath))
 	return nil
 }
 
-// BuildGitCloneArgs builds git clone command arguments
-func BuildGitCloneArgs(sshURL, targetPath string) []string {
-	args := []string{"clone"}
+// runWithRetry runs fn until it succeeds, retrying failures up to performance.clone_retries times with a growing delay
+func runWithRetry(ctx context.Context, name string, fn func() error) error {
+	retries := config.Performance.CloneRetries
+	err := fn()
+	for attempt := 1; err != nil && attempt <= retries && ctx.Err() == nil; attempt++ {
+		printCloneStatus(fmt.Sprintf("%s Retrying %s (attempt %d/%d): %v\n", GetIcon("info"), name, attempt+1, retries+1, err))
+
+		select {
+		case <-time.After(time.Duration(attempt) * retryBackoff):
+		case <-ctx.Done():
+			return err
+		}
+		err = fn()
+	}
+	return err
+}
 
-	if config.Integrations.Git.CloneDepth > 0 {
-		args = append(args, "--depth", fmt.Sprintf("%d", config.Integrations.Git.CloneDepth))
+// removePartialClone deletes a target directory left behind by an interrupted or failed clone
+func removePartialClone(targetPath string) {
+	if err := os.RemoveAll(targetPath); err != nil {
+		printCloneStatus(fmt.Sprintf("%s Could not remove partial clone %s: %v\n", GetIcon("error"), targetPath, err))
 	}
+}
 
-	args = append(args, config.Integrations.Git.CloneArgs...)
-	args = append(args, sshURL, targetPath)
-	return args
+// BuildGitCloneArgs builds git clone command arguments from the global git settings
+func BuildGitCloneArgs(sshURL, targetPath string) []string {
+	return globalCloneOptions().GitArgs(sshURL, targetPath)
 }
 
 // handleCloneError handles clone operation errors
-func handleCloneError(ctx context.Context, err error, repoName string) error {
+func handleCloneError(ctx context.Context, err error, repoName, stderr string) error {
 	if ctx.Err() != nil {
 		return fmt.Errorf("clone of %s cancelled: %w", repoName, ctx.Err())
 	}
-	if exitError, ok := err.(*exec.ExitError); ok {
-		return fmt.Errorf("failed to clone %s: %s", repoName, string(exitError.Stderr))
+	if _, ok := err.(*exec.ExitError); ok {
+		return fmt.Errorf("failed to clone %s: %s", repoName, strings.TrimSpace(stderr))
 	}
 	return fmt.Errorf("failed to clone %s: %w", repoName, err)
 }
-
-// waitForCompletion waits for all clone operations to complete
-func waitForCompletion(wg *sync.WaitGroup, errChan chan error) error {
-	go func() {
-		wg.Wait()
-		close(errChan)
-	}()
-
-	var firstError error
-	for err := range errChan {
-		if err != nil && firstError == nil {
-			firstError = err
-		}
-	}
-
-	if firstError != nil {
-		return firstError
-	}
-
-	return nil
-}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// CloneOptions are the resolved git clone settings for a single repository
+type CloneOptions struct {
+	Protocol    string
+	Depth       int
+	Filter      string
+	SparsePaths []string
+	Args        []string
+	ProjectsDir string
+	PostClone   *CommandConfig
+}
+
+// globalCloneOptions returns the clone settings configured under integrations.git
+func globalCloneOptions() CloneOptions {
+	gitConfig := config.Integrations.Git
+	return CloneOptions{
+		Protocol:    gitConfig.Protocol,
+		Depth:       gitConfig.CloneDepth,
+		Filter:      gitConfig.Filter,
+		SparsePaths: append([]string(nil), gitConfig.SparsePaths...),
+		Args:        append([]string(nil), gitConfig.CloneArgs...),
+	}
+}
+
+// ResolveCloneOptions merges the global git settings with the first clone profile matching the repository
+// and then every matching rule in order, later rules overriding earlier ones
+func ResolveCloneOptions(repo Repo) CloneOptions {
+	opts := globalCloneOptions()
+	if profile, ok := matchCloneProfile(repo); ok {
+		opts = opts.withProfile(profile)
+	}
+
+	for _, rule := range config.Integrations.Git.Rules {
+		if rule.Match.Matches(repo) {
+			opts = opts.withRule(rule)
+		}
+	}
+
+	return opts
+}
+
+// withRule overlays the overrides of a matching clone rule
+func (o CloneOptions) withRule(rule CloneRule) CloneOptions {
+	if profile, ok := config.Integrations.Git.Profiles[rule.Profile]; ok {
+		o = o.withProfile(profile)
+	}
+	if rule.CloneDepth != nil {
+		o.Depth = *rule.CloneDepth
+	}
+	o.Args = append(o.Args, rule.CloneArgs...)
+	if rule.ProjectsDir != "" {
+		o.ProjectsDir = rule.ProjectsDir
+	}
+	if rule.Protocol != "" {
+		o.Protocol = rule.Protocol
+	}
+	if rule.PostClone != nil {
+		o.PostClone = rule.PostClone
+	}
+	return o
+}
+
+// CloneURL returns the URL to clone a repository from using the given protocol
+func CloneURL(htmlURL, protocol string) string {
+	if strings.EqualFold(protocol, ProtocolHTTPS) {
+		return htmlURL
+	}
+	return ConvertToSSHURL(htmlURL)
+}
+
+// Matches reports whether a repository satisfies every condition set on the rule
+func (m RuleMatch) Matches(repo Repo) bool {
+	if m.Owner != "" && !matchGlob(m.Owner, repo.Owner.Login) {
+		return false
+	}
+	if m.Name != "" && !matchGlob(m.Name, repo.Name) {
+		return false
+	}
+	if m.Language != "" && !strings.EqualFold(m.Language, repo.PrimaryLanguage.Name) {
+		return false
+	}
+	if m.Topic != "" && !hasTopic(repo, m.Topic) {
+		return false
+	}
+
+	sizeBytes := int64(repo.DiskUsage) * bytesPerKB
+	if m.MinSize != "" {
+		if minSize, err := ParseSize(m.MinSize); err != nil || sizeBytes < minSize {
+			return false
+		}
+	}
+	if m.MaxSize != "" {
+		if maxSize, err := ParseSize(m.MaxSize); err != nil || sizeBytes > maxSize {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasTopic reports whether the repository is tagged with the topic
+func hasTopic(repo Repo, topic string) bool {
+	for _, name := range repo.TopicNames() {
+		if strings.EqualFold(name, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches a case-insensitive glob pattern
+func matchGlob(pattern, value string) bool {
+	matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(value))
+	return err == nil && matched
+}
+
+// withProfile overlays the settings a clone profile sets; a depth of 0 asks for a full clone
+func (o CloneOptions) withProfile(profile CloneProfile) CloneOptions {
+	if profile.Depth != nil {
+		o.Depth = *profile.Depth
+	}
+	if profile.Filter != "" {
+		o.Filter = profile.Filter
+	}
+	if len(profile.SparsePaths) > 0 {
+		o.SparsePaths = append([]string(nil), profile.SparsePaths...)
+	}
+	o.Args = append(o.Args, profile.Args...)
+	return o
+}
+
+// GitArgs builds the git clone arguments for these options
+func (o CloneOptions) GitArgs(cloneURL, targetPath string) []string {
+	args := []string{"clone"}
+
+	if o.Depth > 0 {
+		args = append(args, "--depth", fmt.Sprintf("%d", o.Depth))
+	}
+	if o.Filter != "" {
+		args = append(args, "--filter="+o.Filter)
+	}
+	if len(o.SparsePaths) > 0 {
+		args = append(args, "--sparse")
+	}
+
+	args = append(args, o.Args...)
+	args = append(args, cloneURL, targetPath)
+	return args
+}
+
+// matchCloneProfile returns the profile selected by the first profile pattern matching the repository
+func matchCloneProfile(repo Repo) (CloneProfile, bool) {
+	gitConfig := config.Integrations.Git
+	for _, match := range gitConfig.ProfilePatterns {
+		if matchRepoPattern(match.Pattern, repo) {
+			profile, ok := gitConfig.Profiles[match.Profile]
+			return profile, ok
+		}
+	}
+	return CloneProfile{}, false
+}
+
+// matchRepoPattern matches a glob against a repository's owner/name, or its bare name when the glob has no owner
+func matchRepoPattern(pattern string, repo Repo) bool {
+	target := repo.Name
+	if strings.Contains(pattern, "/") {
+		target = repo.FullName()
+	}
+	return matchGlob(pattern, target)
+}
+
+// validateCloneFilter checks that a partial clone filter is one git understands
+func validateCloneFilter(filter string) error {
+	switch {
+	case filter == "", filter == "blob:none", filter == "tree:0":
+		return nil
+	case strings.HasPrefix(filter, "blob:limit="):
+		if _, err := ParseSize(strings.TrimPrefix(filter, "blob:limit=")); err != nil {
+			return err
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported filter %q (supported: blob:none, tree:0, blob:limit=<size>)", filter)
+	}
+}
+
+// validateCloneProfiles checks the filters of all profiles and that every pattern points at a defined profile
+func validateCloneProfiles(gitConfig GitConfig) error {
+	if err := validateCloneFilter(gitConfig.Filter); err != nil {
+		return fmt.Errorf("invalid integrations.git.filter: %w", err)
+	}
+	for name, profile := range gitConfig.Profiles {
+		if err := validateCloneFilter(profile.Filter); err != nil {
+			return fmt.Errorf("invalid integrations.git.profiles.%s.filter: %w", name, err)
+		}
+	}
+	for _, match := range gitConfig.ProfilePatterns {
+		if _, err := path.Match(match.Pattern, ""); err != nil {
+			return fmt.Errorf("invalid integrations.git.profile_patterns pattern %q: %w", match.Pattern, err)
+		}
+		if _, ok := gitConfig.Profiles[match.Profile]; !ok {
+			return fmt.Errorf("integrations.git.profile_patterns references unknown profile %q", match.Profile)
+		}
+	}
+	return nil
+}
+
+// validateProtocol checks that a clone protocol is supported
+func validateProtocol(protocol string) error {
+	switch strings.ToLower(protocol) {
+	case "", ProtocolSSH, ProtocolHTTPS:
+		return nil
+	default:
+		return fmt.Errorf("unsupported protocol %q (supported: ssh, https)", protocol)
+	}
+}
+
+// validateCloneRules checks the patterns, sizes, protocols and profiles used by clone rules
+func validateCloneRules(gitConfig GitConfig) error {
+	for i, rule := range gitConfig.Rules {
+		for _, pattern := range []string{rule.Match.Owner, rule.Match.Name} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid integrations.git.rules[%d] pattern %q: %w", i, pattern, err)
+			}
+		}
+		for _, size := range []string{rule.Match.MinSize, rule.Match.MaxSize} {
+			if size == "" {
+				continue
+			}
+			if _, err := ParseSize(size); err != nil {
+				return fmt.Errorf("invalid integrations.git.rules[%d] size: %w", i, err)
+			}
+		}
+		if err := validateProtocol(rule.Protocol); err != nil {
+			return fmt.Errorf("invalid integrations.git.rules[%d].protocol: %w", i, err)
+		}
+		if rule.Profile != "" {
+			if _, ok := gitConfig.Profiles[rule.Profile]; !ok {
+				return fmt.Errorf("integrations.git.rules[%d] references unknown profile %q", i, rule.Profile)
+			}
+		}
+		if rule.ProjectsDir != "" {
+			if _, err := expandPath(rule.ProjectsDir); err != nil {
+				return fmt.Errorf("invalid integrations.git.rules[%d].projects_dir: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func TestParsePickerOutput(t *testing.T) {
+	tests := []struct {
+		name         string
+		output       string
+		expect       bool
+		expectedKey  string
+		expectedKeys []string
+	}{
+		{"action key", "ctrl-o\nuser/a\ta\nacme/b\tb\n", true, "ctrl-o", []string{"user/a", "acme/b"}},
+		{"enter", "\nuser/a\ta\n", true, "", []string{"user/a"}},
+		{"without expect", "user/a\ta\n", false, "", []string{"user/a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, keys := cmd.ParsePickerOutput(tt.output, tt.expect)
+			if key != tt.expectedKey || !reflect.DeepEqual(keys, tt.expectedKeys) {
+				t.Errorf("ParsePickerOutput() = %q, %v, want %q, %v", key, keys, tt.expectedKey, tt.expectedKeys)
+			}
+		})
+	}
+}
+
+func TestRunPickerActionGitHub(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+	repos := createSyncRepos("repo1", "repo2")
+
+	tests := []struct {
+		action   string
+		expected []string
+	}{
+		{cmd.ActionBrowse, []string{"gh", "browse", "--repo", "user/repo2"}},
+		{cmd.ActionStar, []string{"gh", "api", "user/starred/user/repo1", "--method", "PUT"}},
+		{cmd.ActionUnstar, []string{"gh", "api", "user/starred/user/repo2", "--method", "DELETE"}},
+		{cmd.ActionIssues, []string{"gh", "issue", "list", "--repo", "user/repo1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			recorder := recordExecCommands(t)
+			if err := cmd.RunPickerAction(tt.action, repos); err != nil {
+				t.Fatalf("RunPickerAction() returned error: %v", err)
+			}
+			if !recorder.has(tt.expected...) {
+				t.Errorf("expected command %v, got %v", tt.expected, recorder.commands)
+			}
+		})
+	}
+
+	if err := cmd.RunPickerAction("delete", repos); err == nil {
+		t.Error("expected an error for an unknown action")
+	}
+}
+
+func TestRunPickerActionArchiveConfirms(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+	repos := createSyncRepos("repo1")
+
+	recorder := recordExecCommands(t)
+	setConfirmInput(t, "n\n")
+	if err := cmd.RunPickerAction(cmd.ActionArchive, repos); err != nil {
+		t.Fatalf("RunPickerAction() returned error: %v", err)
+	}
+	if recorder.has("archive") {
+		t.Error("expected no archive without confirmation")
+	}
+
+	setConfirmInput(t, "y\n")
+	if err := cmd.RunPickerAction(cmd.ActionArchive, repos); err != nil {
+		t.Fatalf("RunPickerAction() returned error: %v", err)
+	}
+	if !recorder.has("gh", "repo", "archive", "user/repo1", "--yes") {
+		t.Errorf("expected repository archived, got %v", recorder.commands)
+	}
+}
+
+func TestRunPickerActionCopySSH(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+	recorder := recordExecCommands(t)
+
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "wl-copy"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatalf("Failed to write clipboard stub: %v", err)
+	}
+	t.Setenv("PATH", binDir)
+
+	if err := cmd.RunPickerAction(cmd.ActionCopySSH, createSyncRepos("repo1", "repo2")); err != nil {
+		t.Fatalf("RunPickerAction() returned error: %v", err)
+	}
+	if !recorder.has("wl-copy") {
+		t.Errorf("expected SSH URLs copied with wl-copy, got %v", recorder.commands)
+	}
+}
+
+func TestRunPickerActionOpenSkipsRemoteRepos(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+	recorder := recordExecCommands(t)
+	cmd.SetConfig(cmd.Config{
+		Repos:        cmd.ReposConfig{ProjectsDir: "~/Projects"},
+		Integrations: cmd.IntegrationsConfig{PostClone: cmd.CommandConfig{Enabled: true, Command: "tea"}},
+	})
+
+	clonedPath := filepath.Join(ts.env.tmpDir, "Projects", "cloned-repo")
+	createLocalClone(t, clonedPath)
+
+	if err := cmd.RunPickerAction(cmd.ActionOpen, createSyncRepos("cloned-repo", "remote-repo")); err != nil {
+		t.Fatalf("RunPickerAction() returned error: %v", err)
+	}
+	if !recorder.has("tea", clonedPath) {
+		t.Errorf("expected the clone opened with tea, got %v", recorder.commands)
+	}
+	if recorder.has("tea", filepath.Join(ts.env.tmpDir, "Projects", "remote-repo")) {
+		t.Error("expected a repository that is not cloned to be skipped")
+	}
+}
@@ -0,0 +1,160 @@
+package cmd_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func createBareRepo(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(path, "objects"), 0o755); err != nil {
+		t.Fatalf("Failed to create bare repo %s: %v", path, err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "HEAD"), []byte("ref: refs/heads/main\n"), 0o644); err != nil {
+		t.Fatalf("Failed to create bare repo %s: %v", path, err)
+	}
+}
+
+func TestBackupRepos(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recorder := recordExecCommands(t)
+	cmd.SetConfig(cmd.Config{Integrations: cmd.IntegrationsConfig{Git: cmd.GitConfig{Protocol: cmd.ProtocolHTTPS}}})
+
+	dest := filepath.Join(env.tmpDir, "backups")
+	existingMirror := filepath.Join(dest, "user", "existing-repo.git")
+	createBareRepo(t, existingMirror)
+
+	opts := cmd.BackupOptions{Dest: dest, Bundle: true, Wiki: true}
+	results := cmd.BackupRepos(context.Background(), createSyncRepos("new-repo", "existing-repo"), opts)
+
+	expected := map[string]cmd.ResultStatus{"new-repo": cmd.StatusCloned, "existing-repo": cmd.StatusUpdated}
+	for _, result := range results {
+		if result.Status != expected[result.Repo.Name] {
+			t.Errorf("%s status = %s, want %s (err: %v)", result.Repo.Name, result.Status, expected[result.Repo.Name], result.Err)
+		}
+	}
+
+	newMirror := filepath.Join(dest, "user", "new-repo.git")
+	checks := [][]string{
+		{"clone", "--mirror", "https://github.com/user/new-repo", newMirror},
+		{"clone", "--mirror", "https://github.com/user/new-repo.wiki.git", filepath.Join(dest, "user", "new-repo.wiki.git")},
+		{"git", "-C", existingMirror, "remote", "update", "--prune"},
+		{"git", "-C", existingMirror, "bundle", "create", filepath.Join(dest, "bundles", "user", "existing-repo.bundle"), "--all"},
+	}
+	for _, check := range checks {
+		if !recorder.has(check...) {
+			t.Errorf("expected command %v to be run", check)
+		}
+	}
+}
+
+func TestBackupReposKeepsExistingDirectory(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recordExecCommands(t)
+	cmd.SetConfig(cmd.Config{Integrations: cmd.IntegrationsConfig{Git: cmd.GitConfig{Protocol: cmd.ProtocolHTTPS}}})
+
+	dest := filepath.Join(env.tmpDir, "backups")
+	repo := cmd.Repo{Name: "notes", HTMLURL: "fail_clone_url", Owner: cmd.Owner{Login: "user"}}
+	notesFile := filepath.Join(dest, "user", "notes.git", "notes.txt")
+	if err := os.MkdirAll(filepath.Dir(notesFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(notesFile, []byte("keep me"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	results := cmd.BackupRepos(context.Background(), []cmd.Repo{repo}, cmd.BackupOptions{Dest: dest})
+	if results[0].Status != cmd.StatusFailed {
+		t.Errorf("status = %s, want %s", results[0].Status, cmd.StatusFailed)
+	}
+	if _, err := os.Stat(notesFile); err != nil {
+		t.Errorf("a failed mirror clone should not remove a directory it did not create: %v", err)
+	}
+}
+
+func TestBackupReposBundleFailure(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recordExecCommands(t)
+	cmd.SetConfig(cmd.Config{Integrations: cmd.IntegrationsConfig{Git: cmd.GitConfig{Protocol: cmd.ProtocolHTTPS}}})
+
+	dest := filepath.Join(env.tmpDir, "backups")
+	repos := createSyncRepos("broken-bundle")
+	createBareRepo(t, filepath.Join(dest, "user", "broken-bundle.git"))
+	staleBundle := filepath.Join(dest, "bundles", "user", "broken-bundle.bundle")
+	if err := os.MkdirAll(filepath.Dir(staleBundle), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(staleBundle, []byte("old bundle"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := cmd.BackupOptions{Dest: dest, Bundle: true}
+	results := cmd.BackupRepos(context.Background(), repos, opts)
+	if results[0].Status != cmd.StatusFailed || results[0].Err == nil {
+		t.Fatalf("bundle failure should fail the repository, got %s (err: %v)", results[0].Status, results[0].Err)
+	}
+
+	manifestPath, err := cmd.WriteBackupManifest(opts, "user", results)
+	if err != nil {
+		t.Fatalf("WriteBackupManifest() error = %v", err)
+	}
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	var manifest cmd.BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	if entry := manifest.Repositories[0]; entry.Bundle != "" || entry.Error == "" {
+		t.Errorf("stale bundle should be left out of the manifest: %+v", entry)
+	}
+}
+
+func TestWriteBackupManifest(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	dest := filepath.Join(env.tmpDir, "backups")
+	repos := createSyncRepos("good-repo", "bad-repo")
+	mirror := filepath.Join(dest, "user", "good-repo.git")
+	createBareRepo(t, mirror)
+
+	results := cmd.RepoResults{
+		{Repo: repos[0], Path: mirror, Status: cmd.StatusCloned},
+		{Repo: repos[1], Path: filepath.Join(dest, "user", "bad-repo.git"), Status: cmd.StatusFailed, Err: os.ErrPermission},
+	}
+
+	manifestPath, err := cmd.WriteBackupManifest(cmd.BackupOptions{Dest: dest}, "user", results)
+	if err != nil {
+		t.Fatalf("WriteBackupManifest() error = %v", err)
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	var manifest cmd.BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	if manifest.User != "user" || len(manifest.Repositories) != 2 {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	good, bad := manifest.Repositories[0], manifest.Repositories[1]
+	if good.FullName != "user/good-repo" || good.MirrorSize == 0 || good.BackedUpAt.IsZero() {
+		t.Errorf("unexpected entry for good-repo: %+v", good)
+	}
+	if bad.Error == "" || !bad.BackedUpAt.IsZero() {
+		t.Errorf("failed repo should record the error and no backup time: %+v", bad)
+	}
+}
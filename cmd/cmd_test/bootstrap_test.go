@@ -0,0 +1,190 @@
+package cmd_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+// writeProjectFiles creates empty files in dir
+func writeProjectFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// setConfirmInput makes Confirm read the given answer for the duration of the test
+func setConfirmInput(t *testing.T, answer string) {
+	original := cmd.ConfirmInput
+	cmd.ConfirmInput = strings.NewReader(answer)
+	t.Cleanup(func() { cmd.ConfirmInput = original })
+}
+
+func TestDetectEcosystems(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		expected []string
+	}{
+		{"go module", []string{"go.mod"}, []string{cmd.EcosystemGo}},
+		{"npm without lockfile", []string{"package.json"}, []string{cmd.EcosystemNpm}},
+		{"npm lockfile", []string{"package.json", "package-lock.json"}, []string{cmd.EcosystemNpm}},
+		{"pnpm", []string{"package.json", "pnpm-lock.yaml"}, []string{cmd.EcosystemPnpm}},
+		{"yarn", []string{"package.json", "yarn.lock"}, []string{cmd.EcosystemYarn}},
+		{"bun", []string{"package.json", "bun.lockb"}, []string{cmd.EcosystemBun}},
+		{"cargo", []string{"Cargo.toml"}, []string{cmd.EcosystemCargo}},
+		{"uv", []string{"pyproject.toml", "uv.lock"}, []string{cmd.EcosystemUv}},
+		{"poetry", []string{"pyproject.toml", "poetry.lock"}, []string{cmd.EcosystemPoetry}},
+		{"pip", []string{"pyproject.toml"}, []string{cmd.EcosystemPip}},
+		{"bundler", []string{"Gemfile"}, []string{cmd.EcosystemBundler}},
+		{"polyglot", []string{"go.mod", "package.json", "yarn.lock"}, []string{cmd.EcosystemGo, cmd.EcosystemYarn}},
+		{"lockfile without manifest", []string{"yarn.lock"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeProjectFiles(t, dir, tt.files...)
+			if got := cmd.DetectEcosystems(dir); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("DetectEcosystems() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlanBootstrapUsesCommandOverrides(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	cmd.SetConfig(cmd.Config{
+		Repos: cmd.ReposConfig{ProjectsDir: "~/Projects"},
+		Integrations: cmd.IntegrationsConfig{Bootstrap: cmd.BootstrapConfig{
+			Commands: map[string][]string{cmd.EcosystemNpm: {"npm", "ci"}},
+		}},
+	})
+
+	repos := createHookRepos()
+	writeProjectFiles(t, filepath.Join(env.tmpDir, "Projects", "go-repo"), "go.mod")
+	writeProjectFiles(t, filepath.Join(env.tmpDir, "Projects", "js-repo"), "package.json")
+
+	plans := cmd.PlanBootstrap(append(repos, cmd.Repo{Name: "empty-repo", Owner: cmd.Owner{Login: "user"}}))
+	if len(plans) != 2 {
+		t.Fatalf("expected 2 plans, got %d", len(plans))
+	}
+	if expected := [][]string{{"go", "mod", "download"}}; !reflect.DeepEqual(plans[0].Commands, expected) {
+		t.Errorf("go-repo commands = %v, want %v", plans[0].Commands, expected)
+	}
+	if expected := [][]string{{"npm", "ci"}}; !reflect.DeepEqual(plans[1].Commands, expected) {
+		t.Errorf("js-repo commands = %v, want %v", plans[1].Commands, expected)
+	}
+}
+
+func TestRunBootstrap(t *testing.T) {
+	tests := []struct {
+		name        string
+		bootstrap   cmd.BootstrapConfig
+		answer      string
+		expectGo    bool
+		expectNpm   bool
+		expectCount int
+	}{
+		{"disabled", cmd.BootstrapConfig{Allow: []string{"user/*"}}, "", false, false, 0},
+		{"allowlisted only without confirm", cmd.BootstrapConfig{Enabled: true, Allow: []string{"go-repo"}}, "", true, false, 1},
+		{"confirmed", cmd.BootstrapConfig{Enabled: true, Confirm: true, Allow: []string{"go-repo"}}, "y\n", true, true, 2},
+		{"declined", cmd.BootstrapConfig{Enabled: true, Confirm: true, Allow: []string{"go-repo"}}, "n\n", true, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := setupTempHome(t)
+			defer env.cleanup()
+			recorder := recordExecCommands(t)
+			setConfirmInput(t, tt.answer)
+			cmd.SetConfig(cmd.Config{
+				Repos:        cmd.ReposConfig{ProjectsDir: "~/Projects"},
+				Integrations: cmd.IntegrationsConfig{Bootstrap: tt.bootstrap},
+			})
+
+			writeProjectFiles(t, filepath.Join(env.tmpDir, "Projects", "go-repo"), "go.mod")
+			writeProjectFiles(t, filepath.Join(env.tmpDir, "Projects", "js-repo"), "package.json")
+
+			results := cmd.RunBootstrap(context.Background(), createHookRepos())
+			if len(results) != tt.expectCount {
+				t.Errorf("expected %d results, got %d", tt.expectCount, len(results))
+			}
+			if got := recorder.has("go", "mod", "download"); got != tt.expectGo {
+				t.Errorf("go mod download executed = %v, want %v", got, tt.expectGo)
+			}
+			if got := recorder.has("npm", "install"); got != tt.expectNpm {
+				t.Errorf("npm install executed = %v, want %v", got, tt.expectNpm)
+			}
+		})
+	}
+}
+
+func TestRunBootstrapCapturesFailure(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recordExecCommands(t)
+	cmd.SetConfig(cmd.Config{
+		Repos: cmd.ReposConfig{ProjectsDir: "~/Projects"},
+		Integrations: cmd.IntegrationsConfig{Bootstrap: cmd.BootstrapConfig{
+			Enabled:  true,
+			Allow:    []string{"user/*"},
+			Commands: map[string][]string{cmd.EcosystemGo: {"failing-hook"}},
+		}},
+	})
+	writeProjectFiles(t, filepath.Join(env.tmpDir, "Projects", "go-repo"), "go.mod")
+
+	results := cmd.RunBootstrap(context.Background(), createHookRepos()[:1])
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Err == nil {
+		t.Error("expected bootstrap error for failing install command")
+	}
+	if !strings.Contains(results[0].Output, "$ failing-hook") {
+		t.Errorf("expected captured output to include the command, got %q", results[0].Output)
+	}
+}
+
+func TestRunBootstrapDryRun(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	enableDryRun(t)
+	recorder := recordExecCommands(t)
+	cmd.SetConfig(cmd.Config{
+		Repos: cmd.ReposConfig{ProjectsDir: "~/Projects"},
+		Integrations: cmd.IntegrationsConfig{Bootstrap: cmd.BootstrapConfig{
+			Enabled: true,
+			Allow:   []string{"user/*"},
+		}},
+	})
+	writeProjectFiles(t, filepath.Join(env.tmpDir, "Projects", "go-repo"), "go.mod")
+
+	if results := cmd.RunBootstrap(context.Background(), createHookRepos()); results != nil {
+		t.Errorf("expected no results in dry run, got %v", results)
+	}
+	if recorder.has("go", "mod", "download") {
+		t.Error("expected no install commands in dry run")
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	for answer, expected := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+		setConfirmInput(t, answer)
+		if got := cmd.Confirm("Continue?"); got != expected {
+			t.Errorf("Confirm() with %q = %v, want %v", answer, got, expected)
+		}
+	}
+}
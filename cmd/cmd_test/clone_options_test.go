@@ -0,0 +1,320 @@
+package cmd_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func createProfileConfig() cmd.Config {
+	return cmd.Config{
+		Repos: cmd.ReposConfig{ProjectsDir: "~/Projects", PerUserDir: true},
+		Integrations: cmd.IntegrationsConfig{
+			Git: cmd.GitConfig{
+				CloneArgs: []string{"--recurse-submodules"},
+				Profiles: map[string]cmd.CloneProfile{
+					"monorepo": {Filter: "blob:none", SparsePaths: []string{"services/api", "libs"}},
+					"history":  {Depth: intPtr(1), Filter: "tree:0", Args: []string{"--single-branch"}},
+				},
+				ProfilePatterns: []cmd.ProfilePattern{
+					{Pattern: "acme/monorepo", Profile: "monorepo"},
+					{Pattern: "archive-*", Profile: "history"},
+				},
+			},
+		},
+	}
+}
+
+func TestResolveCloneOptions(t *testing.T) {
+	cmd.SetConfig(createProfileConfig())
+
+	tests := []struct {
+		name     string
+		repo     cmd.Repo
+		expected cmd.CloneOptions
+	}{
+		{
+			name: "owner/name pattern selects profile",
+			repo: cmd.Repo{Name: "monorepo", Owner: cmd.Owner{Login: "acme"}},
+			expected: cmd.CloneOptions{
+				Filter:      "blob:none",
+				SparsePaths: []string{"services/api", "libs"},
+				Args:        []string{"--recurse-submodules"},
+			},
+		},
+		{
+			name: "name glob selects profile",
+			repo: cmd.Repo{Name: "archive-2019", Owner: cmd.Owner{Login: "someone"}},
+			expected: cmd.CloneOptions{
+				Depth:  1,
+				Filter: "tree:0",
+				Args:   []string{"--recurse-submodules", "--single-branch"},
+			},
+		},
+		{
+			name: "no match keeps global settings",
+			repo: cmd.Repo{Name: "monorepo", Owner: cmd.Owner{Login: "other"}},
+			expected: cmd.CloneOptions{
+				Args: []string{"--recurse-submodules"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := cmd.ResolveCloneOptions(tt.repo)
+			if opts.Depth != tt.expected.Depth || opts.Filter != tt.expected.Filter ||
+				!reflect.DeepEqual(opts.SparsePaths, tt.expected.SparsePaths) || !reflect.DeepEqual(opts.Args, tt.expected.Args) {
+				t.Errorf("ResolveCloneOptions() = %+v, want %+v", opts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveCloneOptionsProfileFullClone(t *testing.T) {
+	cfg := createProfileConfig()
+	cfg.Integrations.Git.CloneDepth = 1
+	cfg.Integrations.Git.Profiles["full"] = cmd.CloneProfile{Depth: intPtr(0)}
+	cfg.Integrations.Git.ProfilePatterns = []cmd.ProfilePattern{{Pattern: "acme/*", Profile: "full"}}
+	cmd.SetConfig(cfg)
+
+	if opts := cmd.ResolveCloneOptions(cmd.Repo{Name: "tool", Owner: cmd.Owner{Login: "acme"}}); opts.Depth != 0 {
+		t.Errorf("Depth = %d, want 0 from the profile", opts.Depth)
+	}
+	if opts := cmd.ResolveCloneOptions(cmd.Repo{Name: "tool", Owner: cmd.Owner{Login: "other"}}); opts.Depth != 1 {
+		t.Errorf("Depth = %d, want the global 1 without a profile", opts.Depth)
+	}
+}
+
+func TestCloneOptionsGitArgs(t *testing.T) {
+	opts := cmd.CloneOptions{
+		Depth:       1,
+		Filter:      "blob:none",
+		SparsePaths: []string{"docs"},
+		Args:        []string{"--single-branch"},
+	}
+
+	args := opts.GitArgs("git@github.com:user/repo.git", "/target/path")
+	expected := []string{"clone", "--depth", "1", "--filter=blob:none", "--sparse", "--single-branch", "git@github.com:user/repo.git", "/target/path"}
+
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("GitArgs() = %v, want %v", args, expected)
+	}
+}
+
+func TestCloneWithSparseProfile(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recorder := recordExecCommands(t)
+	cmd.SetConfig(createProfileConfig())
+
+	repo := cmd.Repo{Name: "monorepo", HTMLURL: "https://github.com/acme/monorepo", Owner: cmd.Owner{Login: "acme"}}
+	results := cmd.CloneReposWithResults(context.Background(), []cmd.Repo{repo})
+	if results[0].Status != cmd.StatusCloned {
+		t.Fatalf("status = %s, want %s (err: %v)", results[0].Status, cmd.StatusCloned, results[0].Err)
+	}
+
+	targetPath := filepath.Join(env.tmpDir, "Projects", "acme", "monorepo")
+	if !recorder.has("clone", "--filter=blob:none", "--sparse") {
+		t.Errorf("expected partial sparse clone, got %v", recorder.commands)
+	}
+	if !recorder.has("git", "-C", targetPath, "sparse-checkout", "set", "services/api", "libs") {
+		t.Errorf("expected sparse-checkout set for profile paths, got %v", recorder.commands)
+	}
+}
+
+func TestConfigCloneProfilesValidation(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	tests := []struct {
+		name    string
+		content string
+		valid   bool
+	}{
+		{
+			name: "valid profiles",
+			content: `integrations:
+  git:
+    filter: blob:limit=1m
+    profiles:
+      monorepo:
+        filter: tree:0
+        sparse_paths: [services]
+    profile_patterns:
+      - pattern: acme/*
+        profile: monorepo`,
+			valid: true,
+		},
+		{
+			name: "unknown filter",
+			content: `integrations:
+  git:
+    filter: sparse:oid=abc`,
+			valid: false,
+		},
+		{
+			name: "pattern with unknown profile",
+			content: `integrations:
+  git:
+    profile_patterns:
+      - pattern: acme/*
+        profile: missing`,
+			valid: false,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(env.tmpDir, fmt.Sprintf("profiles-%d.yml", i))
+			if err := os.WriteFile(configPath, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			config := cmd.LoadConfig(configPath)
+			loaded := len(config.Integrations.Git.Profiles) > 0 || config.Integrations.Git.Filter != ""
+			if loaded != tt.valid {
+				t.Errorf("LoadConfig() kept config = %v, want %v", loaded, tt.valid)
+			}
+		})
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func createRulesConfig() cmd.Config {
+	cfg := createProfileConfig()
+	cfg.Integrations.Git.Protocol = "ssh"
+	cfg.Integrations.Git.Rules = []cmd.CloneRule{
+		{
+			Match:      cmd.RuleMatch{MinSize: "500MB"},
+			CloneDepth: intPtr(1),
+		},
+		{
+			Match:       cmd.RuleMatch{Owner: "work-org"},
+			ProjectsDir: "~/work",
+			Protocol:    "https",
+			PostClone:   &cmd.CommandConfig{Enabled: true, Command: "code"},
+		},
+		{
+			Match:     cmd.RuleMatch{Language: "go", Topic: "cli", Name: "gh-*"},
+			CloneArgs: []string{"--no-tags"},
+			Profile:   "history",
+		},
+	}
+	return cfg
+}
+
+func TestRuleMatch(t *testing.T) {
+	repo := cmd.Repo{
+		Name:            "gh-tool",
+		Owner:           cmd.Owner{Login: "Work-Org"},
+		DiskUsage:       600 * 1024,
+		Topics:          []cmd.Topic{{Name: "CLI"}},
+		PrimaryLanguage: cmd.Language{Name: "Go"},
+	}
+
+	tests := []struct {
+		name     string
+		match    cmd.RuleMatch
+		expected bool
+	}{
+		{"empty match", cmd.RuleMatch{}, true},
+		{"owner glob", cmd.RuleMatch{Owner: "work-*"}, true},
+		{"owner mismatch", cmd.RuleMatch{Owner: "personal"}, false},
+		{"name glob", cmd.RuleMatch{Name: "gh-*"}, true},
+		{"language", cmd.RuleMatch{Language: "GO"}, true},
+		{"language mismatch", cmd.RuleMatch{Language: "Rust"}, false},
+		{"topic", cmd.RuleMatch{Topic: "cli"}, true},
+		{"topic mismatch", cmd.RuleMatch{Topic: "web"}, false},
+		{"min size", cmd.RuleMatch{MinSize: "500MB"}, true},
+		{"min size too large", cmd.RuleMatch{MinSize: "1GB"}, false},
+		{"max size", cmd.RuleMatch{MaxSize: "1GB"}, true},
+		{"max size too small", cmd.RuleMatch{MaxSize: "100MB"}, false},
+		{"all conditions", cmd.RuleMatch{Owner: "work-org", Language: "go", Topic: "cli", MinSize: "1MB"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.match.Matches(repo); got != tt.expected {
+				t.Errorf("Matches() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveCloneOptionsWithRules(t *testing.T) {
+	cmd.SetConfig(createRulesConfig())
+
+	t.Run("large repo gets shallow clone", func(t *testing.T) {
+		opts := cmd.ResolveCloneOptions(cmd.Repo{Name: "huge", Owner: cmd.Owner{Login: "me"}, DiskUsage: 700 * 1024})
+		if opts.Depth != 1 {
+			t.Errorf("Depth = %d, want 1", opts.Depth)
+		}
+		if opts.ProjectsDir != "" || opts.Protocol != "ssh" {
+			t.Errorf("unexpected overrides: %+v", opts)
+		}
+	})
+
+	t.Run("later rules override earlier ones", func(t *testing.T) {
+		opts := cmd.ResolveCloneOptions(cmd.Repo{
+			Name:            "gh-tool",
+			Owner:           cmd.Owner{Login: "work-org"},
+			DiskUsage:       700 * 1024,
+			Topics:          []cmd.Topic{{Name: "cli"}},
+			PrimaryLanguage: cmd.Language{Name: "Go"},
+		})
+
+		if opts.Depth != 1 || opts.Filter != "tree:0" {
+			t.Errorf("expected profile from rule to apply, got %+v", opts)
+		}
+		if opts.ProjectsDir != "~/work" || opts.Protocol != "https" {
+			t.Errorf("expected work-org overrides, got %+v", opts)
+		}
+		if opts.PostClone == nil || opts.PostClone.Command != "code" {
+			t.Errorf("expected post-clone override, got %+v", opts.PostClone)
+		}
+		expectedArgs := []string{"--recurse-submodules", "--single-branch", "--no-tags"}
+		if !reflect.DeepEqual(opts.Args, expectedArgs) {
+			t.Errorf("Args = %v, want %v", opts.Args, expectedArgs)
+		}
+	})
+}
+
+func TestCloneURL(t *testing.T) {
+	htmlURL := "https://github.com/user/repo"
+	if got := cmd.CloneURL(htmlURL, "ssh"); got != "git@github.com:user/repo.git" {
+		t.Errorf("CloneURL(ssh) = %q", got)
+	}
+	if got := cmd.CloneURL(htmlURL, ""); got != "git@github.com:user/repo.git" {
+		t.Errorf("CloneURL(default) = %q", got)
+	}
+	if got := cmd.CloneURL(htmlURL, "https"); got != htmlURL {
+		t.Errorf("CloneURL(https) = %q", got)
+	}
+}
+
+func TestCloneWithRuleTargetDirectory(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recorder := recordExecCommands(t)
+	cmd.SetConfig(createRulesConfig())
+
+	repo := cmd.Repo{Name: "service", HTMLURL: "https://github.com/work-org/service", Owner: cmd.Owner{Login: "work-org"}}
+	results := cmd.CloneReposWithResults(context.Background(), []cmd.Repo{repo})
+
+	expectedPath := filepath.Join(env.tmpDir, "work", "work-org", "service")
+	if results[0].Path != expectedPath {
+		t.Errorf("Path = %q, want %q", results[0].Path, expectedPath)
+	}
+	if !recorder.has("https://github.com/work-org/service", expectedPath) {
+		t.Errorf("expected https clone into rule directory, got %v", recorder.commands)
+	}
+}
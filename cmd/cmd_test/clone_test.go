@@ -154,6 +154,7 @@ func TestConcurrentCloning(t *testing.T) {
 func TestCloneReposWithExistingDirectories(t *testing.T) {
 	env := setupTempHome(t)
 	defer env.cleanup()
+	recorder := recordExecCommands(t)
 
 	cmd.SetConfig(cmd.Config{
 		Repos: cmd.ReposConfig{
@@ -162,15 +163,6 @@ func TestCloneReposWithExistingDirectories(t *testing.T) {
 		},
 	})
 
-	originalExecCommand := cmd.ExecCommand
-	defer func() { cmd.ExecCommand = originalExecCommand }()
-
-	var executedCommands [][]string
-	cmd.ExecCommand = func(command string, args ...string) *exec.Cmd {
-		executedCommands = append(executedCommands, append([]string{command}, args...))
-		return exec.Command("echo", "mock command")
-	}
-
 	projectsDir := filepath.Join(env.tmpDir, "Projects", "user")
 	err := os.MkdirAll(projectsDir, 0o755)
 	if err != nil {
@@ -188,13 +180,20 @@ func TestCloneReposWithExistingDirectories(t *testing.T) {
 		{Name: "new-repo", HTMLURL: "https://github.com/user/new-repo", Owner: cmd.Owner{Login: "user"}},
 	}
 
-	err = cmd.CloneRepos(repos)
-	if err != nil {
-		t.Errorf("CloneRepos() returned error: %v", err)
+	results := cmd.CloneReposWithResults(context.Background(), repos)
+	if err := results.Err(); err != nil {
+		t.Errorf("CloneReposWithResults() returned error: %v", err)
+	}
+
+	expected := map[string]cmd.ResultStatus{"existing-repo": cmd.StatusSkipped, "new-repo": cmd.StatusCloned}
+	for _, result := range results {
+		if result.Status != expected[result.Repo.Name] {
+			t.Errorf("%s status = %s, want %s", result.Repo.Name, result.Status, expected[result.Repo.Name])
+		}
 	}
 
 	gitCloneCommands := 0
-	for _, cmd := range executedCommands {
+	for _, cmd := range recorder.commands {
 		if len(cmd) >= 2 && cmd[0] == "git" && cmd[1] == "clone" {
 			gitCloneCommands++
 		}
@@ -260,3 +259,235 @@ func TestBuildGitCloneArgs(t *testing.T) {
 		t.Errorf("BuildGitCloneArgs() = %v, want %v", args, expected)
 	}
 }
+
+func TestCloneReposWithResults(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+
+	existingPath := filepath.Join(ts.env.tmpDir, "Projects", "user", "existing-repo")
+	if err := os.MkdirAll(existingPath, 0o755); err != nil {
+		t.Fatalf("Failed to create existing repo directory: %v", err)
+	}
+
+	repos := []cmd.Repo{
+		{Name: "new-repo", HTMLURL: "https://github.com/user/new-repo", Owner: cmd.Owner{Login: "user"}},
+		{Name: "existing-repo", HTMLURL: "https://github.com/user/existing-repo", Owner: cmd.Owner{Login: "user"}},
+		{Name: "fail_repo", HTMLURL: "fail_clone_url", Owner: cmd.Owner{Login: "user"}},
+	}
+
+	results := cmd.CloneReposWithResults(context.Background(), repos)
+	if len(results) != len(repos) {
+		t.Fatalf("CloneReposWithResults() returned %d results, want %d", len(results), len(repos))
+	}
+
+	expected := []cmd.ResultStatus{cmd.StatusCloned, cmd.StatusSkipped, cmd.StatusFailed}
+	for i, status := range expected {
+		if results[i].Repo.Name != repos[i].Name {
+			t.Errorf("result %d is for %s, want %s", i, results[i].Repo.Name, repos[i].Name)
+		}
+		if results[i].Status != status {
+			t.Errorf("result for %s has status %s, want %s", repos[i].Name, results[i].Status, status)
+		}
+	}
+
+	if results[2].Err == nil {
+		t.Error("failed clone should carry an error")
+	}
+	if code := results.ExitCode(); code != cmd.ExitCodePartialFailure {
+		t.Errorf("ExitCode() = %d, want %d", code, cmd.ExitCodePartialFailure)
+	}
+}
+
+func TestCloneReposOnExisting(t *testing.T) {
+	tests := []struct {
+		name           string
+		onExisting     string
+		repoName       string
+		expectedStatus cmd.ResultStatus
+		expectedGit    [][]string
+		unexpectedGit  [][]string
+	}{
+		{
+			name:           "skip leaves matching clone untouched",
+			onExisting:     "skip",
+			repoName:       "existing-repo",
+			expectedStatus: cmd.StatusSkipped,
+			unexpectedGit:  [][]string{{"fetch"}, {"clone"}},
+		},
+		{
+			name:           "fetch updates remote refs",
+			onExisting:     "fetch",
+			repoName:       "existing-repo",
+			expectedStatus: cmd.StatusUpdated,
+			expectedGit:    [][]string{{"fetch", "--prune", "origin"}},
+			unexpectedGit:  [][]string{{"merge"}},
+		},
+		{
+			name:           "pull fast-forwards the default branch",
+			onExisting:     "pull",
+			repoName:       "existing-repo",
+			expectedStatus: cmd.StatusUpdated,
+			expectedGit:    [][]string{{"fetch", "--prune", "origin"}, {"merge", "--ff-only", "origin/main"}},
+		},
+		{
+			name:           "pull leaves a dirty clone alone",
+			onExisting:     "pull",
+			repoName:       "dirty-repo",
+			expectedStatus: cmd.StatusDirty,
+			expectedGit:    [][]string{{"status", "--porcelain"}},
+			unexpectedGit:  [][]string{{"fetch"}, {"merge"}},
+		},
+		{
+			name:           "error fails without touching the directory",
+			onExisting:     "error",
+			repoName:       "existing-repo",
+			expectedStatus: cmd.StatusFailed,
+			unexpectedGit:  [][]string{{"remote", "get-url"}, {"fetch"}},
+		},
+		{
+			name:           "mismatched origin is reported",
+			onExisting:     "pull",
+			repoName:       "unrelated-repo",
+			expectedStatus: cmd.StatusMismatch,
+			unexpectedGit:  [][]string{{"fetch"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := setupTempHome(t)
+			defer env.cleanup()
+			recorder := recordExecCommands(t)
+
+			cmd.SetConfig(cmd.Config{
+				Repos:        cmd.ReposConfig{ProjectsDir: "~/Projects", PerUserDir: true},
+				Integrations: cmd.IntegrationsConfig{Git: cmd.GitConfig{OnExisting: tt.onExisting}},
+			})
+
+			existingPath := filepath.Join(env.tmpDir, "Projects", "user", tt.repoName)
+			if err := os.MkdirAll(existingPath, 0o755); err != nil {
+				t.Fatalf("Failed to create existing repo directory: %v", err)
+			}
+
+			repo := cmd.Repo{Name: tt.repoName, HTMLURL: "https://github.com/user/" + tt.repoName, Owner: cmd.Owner{Login: "user"}}
+			results := cmd.CloneReposWithResults(context.Background(), []cmd.Repo{repo})
+
+			if results[0].Status != tt.expectedStatus {
+				t.Errorf("status = %s, want %s (err: %v)", results[0].Status, tt.expectedStatus, results[0].Err)
+			}
+			if failed := tt.expectedStatus.IsFailure(); failed != (results.ExitCode() != 0) {
+				t.Errorf("ExitCode() = %d for status %s", results.ExitCode(), results[0].Status)
+			}
+			for _, args := range tt.expectedGit {
+				if !recorder.has(args...) {
+					t.Errorf("expected git %v to run, got %v", args, recorder.commands)
+				}
+			}
+			for _, args := range tt.unexpectedGit {
+				if recorder.has(args...) {
+					t.Errorf("did not expect git %v to run, got %v", args, recorder.commands)
+				}
+			}
+		})
+	}
+}
+
+func TestCloneForkAddsUpstream(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recorder := recordExecCommands(t)
+
+	fork := cmd.Repo{Name: "forked-repo", HTMLURL: "https://github.com/user/forked-repo", Owner: cmd.Owner{Login: "user"}, IsFork: true}
+	targetPath := filepath.Join(env.tmpDir, "Projects", "user", "forked-repo")
+
+	t.Run("adds upstream and tracks parent branch", func(t *testing.T) {
+		cmd.SetConfig(cmd.Config{
+			Repos: cmd.ReposConfig{ProjectsDir: "~/Projects", PerUserDir: true},
+			Integrations: cmd.IntegrationsConfig{Git: cmd.GitConfig{
+				Forks: cmd.ForkConfig{AddUpstream: true, RemoteName: "upstream", TrackUpstream: true},
+			}},
+		})
+
+		results := cmd.CloneReposWithResults(context.Background(), []cmd.Repo{fork})
+		if results[0].Status != cmd.StatusCloned {
+			t.Fatalf("status = %s, want %s (err: %v)", results[0].Status, cmd.StatusCloned, results[0].Err)
+		}
+
+		expected := [][]string{
+			{"git", "-C", targetPath, "remote", "add", "upstream", "git@github.com:upstream/forked-repo.git"},
+			{"git", "-C", targetPath, "fetch", "upstream", "develop"},
+			{"git", "-C", targetPath, "branch", "--set-upstream-to=upstream/develop", "main"},
+		}
+		for _, args := range expected {
+			if !recorder.has(args...) {
+				t.Errorf("expected %v to run, got %v", args, recorder.commands)
+			}
+		}
+	})
+
+	t.Run("disabled upstream setup", func(t *testing.T) {
+		recorder.commands = nil
+		cmd.SetConfig(cmd.Config{
+			Repos: cmd.ReposConfig{ProjectsDir: "~/Projects", PerUserDir: true},
+		})
+
+		cmd.CloneReposWithResults(context.Background(), []cmd.Repo{fork})
+		if recorder.has("remote", "add") {
+			t.Errorf("upstream remote should not be added when disabled, got %v", recorder.commands)
+		}
+	})
+}
+
+func TestCloneReposRetriesFailedClone(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	cmd.SetConfig(cmd.Config{
+		Repos:       cmd.ReposConfig{ProjectsDir: "~/Projects"},
+		Performance: cmd.PerformanceConfig{CloneRetries: 1},
+	})
+
+	originalExecCommand := cmd.ExecCommand
+	defer func() { cmd.ExecCommand = originalExecCommand }()
+
+	attempts := 0
+	cmd.ExecCommand = func(command string, args ...string) *exec.Cmd {
+		attempts++
+		if attempts == 1 {
+			return exec.Command("false")
+		}
+		return exec.Command("true")
+	}
+
+	results := cmd.CloneReposWithResults(context.Background(), []cmd.Repo{{Name: "flaky-repo", HTMLURL: "https://github.com/user/flaky-repo"}})
+	if attempts != 2 {
+		t.Errorf("expected 2 clone attempts, got %d", attempts)
+	}
+	if results[0].Status != cmd.StatusCloned {
+		t.Errorf("status = %s, want %s (err: %v)", results[0].Status, cmd.StatusCloned, results[0].Err)
+	}
+}
+
+func TestCloneReposCancelRemovesPartialClone(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	cmd.SetConfig(cmd.Config{Repos: cmd.ReposConfig{ProjectsDir: "~/Projects"}})
+
+	originalExecCommand := cmd.ExecCommand
+	defer func() { cmd.ExecCommand = originalExecCommand }()
+
+	cmd.ExecCommand = func(command string, args ...string) *exec.Cmd {
+		target := args[len(args)-1]
+		return exec.Command("sh", "-c", "mkdir -p \"$1\" && exec sleep 10", "sh", target)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	results := cmd.CloneReposWithResults(ctx, []cmd.Repo{{Name: "partial-repo", HTMLURL: "https://github.com/user/partial-repo"}})
+	if results[0].Status != cmd.StatusCancelled {
+		t.Errorf("status = %s, want %s", results[0].Status, cmd.StatusCancelled)
+	}
+	if _, err := os.Stat(filepath.Join(env.tmpDir, "Projects", "partial-repo")); !os.IsNotExist(err) {
+		t.Error("partial clone directory should be removed after cancellation")
+	}
+}
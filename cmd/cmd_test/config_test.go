@@ -1,6 +1,7 @@
 package cmd_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -226,3 +227,290 @@ func TestConfigValidation(t *testing.T) {
 		}
 	})
 }
+
+func TestConfigOnExisting(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	t.Run("defaults to skip", func(t *testing.T) {
+		config := cmd.LoadConfig(filepath.Join(env.tmpDir, "missing.yml"))
+		if config.Integrations.Git.OnExisting != "skip" {
+			t.Errorf("Expected default on_existing to be skip, got %q", config.Integrations.Git.OnExisting)
+		}
+	})
+
+	t.Run("accepts pull", func(t *testing.T) {
+		configPath := filepath.Join(env.tmpDir, "on-existing-pull.yml")
+		configContent := `integrations:
+  git:
+    on_existing: pull`
+		if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+
+		config := cmd.LoadConfig(configPath)
+		if config.Integrations.Git.OnExisting != "pull" {
+			t.Errorf("Expected on_existing to be pull, got %q", config.Integrations.Git.OnExisting)
+		}
+	})
+
+	t.Run("rejects unknown values", func(t *testing.T) {
+		configPath := filepath.Join(env.tmpDir, "on-existing-invalid.yml")
+		configContent := `integrations:
+  git:
+    on_existing: overwrite`
+		if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+
+		config := cmd.LoadConfig(configPath)
+		if config.Integrations.Git.OnExisting != "skip" {
+			t.Errorf("Expected fallback on_existing to be skip, got %q", config.Integrations.Git.OnExisting)
+		}
+	})
+}
+
+func TestConfigCloneRules(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	t.Run("valid rules are loaded", func(t *testing.T) {
+		configPath := filepath.Join(env.tmpDir, "rules-valid.yml")
+		configContent := `integrations:
+  git:
+    rules:
+      - match:
+          min_size: 500MB
+        clone_depth: 1
+      - match:
+          owner: work-org
+        projects_dir: ~/work
+        protocol: https`
+		if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+
+		config := cmd.LoadConfig(configPath)
+		rules := config.Integrations.Git.Rules
+		if len(rules) != 2 {
+			t.Fatalf("Expected 2 rules, got %d", len(rules))
+		}
+		if rules[0].CloneDepth == nil || *rules[0].CloneDepth != 1 {
+			t.Errorf("Expected first rule clone_depth 1, got %v", rules[0].CloneDepth)
+		}
+		if rules[1].ProjectsDir != "~/work" || rules[1].Protocol != "https" {
+			t.Errorf("Unexpected second rule: %+v", rules[1])
+		}
+	})
+
+	t.Run("invalid rule size falls back to defaults", func(t *testing.T) {
+		configPath := filepath.Join(env.tmpDir, "rules-invalid.yml")
+		configContent := `integrations:
+  git:
+    rules:
+      - match:
+          min_size: huge
+        clone_depth: 1`
+		if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+
+		config := cmd.LoadConfig(configPath)
+		if len(config.Integrations.Git.Rules) != 0 {
+			t.Errorf("Expected invalid rules to be rejected, got %+v", config.Integrations.Git.Rules)
+		}
+	})
+}
+
+func TestConfigTmux(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	t.Run("windows and layout are loaded", func(t *testing.T) {
+		configPath := filepath.Join(env.tmpDir, "tmux-valid.yml")
+		configContent := `integrations:
+  tmux:
+    enabled: true
+    mode: window
+    windows:
+      - name: code
+        layout: main-vertical
+        panes: [nvim ., ""]`
+		if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+
+		config := cmd.LoadConfig(configPath)
+		tmux := config.Integrations.Tmux
+		if !tmux.Enabled || tmux.Mode != cmd.TmuxModeWindow || !tmux.Switch || tmux.Session != "repos" {
+			t.Errorf("Unexpected tmux config: %+v", tmux)
+		}
+		if len(tmux.Windows) != 1 || len(tmux.Windows[0].Panes) != 2 {
+			t.Errorf("Expected one window with two panes, got %+v", tmux.Windows)
+		}
+	})
+
+	t.Run("rejects unknown modes", func(t *testing.T) {
+		configPath := filepath.Join(env.tmpDir, "tmux-invalid.yml")
+		configContent := `integrations:
+  tmux:
+    enabled: true
+    mode: pane`
+		if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+
+		config := cmd.LoadConfig(configPath)
+		if config.Integrations.Tmux.Enabled {
+			t.Error("Expected invalid tmux config to fall back to defaults")
+		}
+	})
+}
+
+func TestConfigViews(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	t.Run("views are loaded", func(t *testing.T) {
+		configPath := filepath.Join(env.tmpDir, "views-valid.yml")
+		configContent := `views:
+  backend:
+    users: [acme, widgets]
+    type: source
+    language: go
+    sort: pushed
+  tools:
+    source: search
+    query: topic:cli`
+		if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+
+		config := cmd.LoadConfig(configPath)
+		backend := config.Views["backend"]
+		if len(backend.Users) != 2 || backend.Type != "source" || backend.Sort != "pushed" {
+			t.Errorf("Unexpected backend view: %+v", backend)
+		}
+		if config.Views["tools"].Source != cmd.ViewSourceSearch {
+			t.Errorf("Unexpected tools view: %+v", config.Views["tools"])
+		}
+	})
+
+	t.Run("rejects invalid views", func(t *testing.T) {
+		for i, view := range []string{"source: forks", "source: search", "sort: popularity", "users: ['bad;user']", "filter: 'stars:>'"} {
+			configPath := filepath.Join(env.tmpDir, fmt.Sprintf("views-invalid-%d.yml", i))
+			configContent := "views:\n  broken:\n    " + view
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			if config := cmd.LoadConfig(configPath); len(config.Views) != 0 {
+				t.Errorf("Expected invalid view %q to fall back to defaults, got %+v", view, config.Views)
+			}
+		}
+	})
+}
+
+func TestConfigColumns(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	configPath := filepath.Join(env.tmpDir, "columns.yml")
+	if err := os.WriteFile(configPath, []byte("ui:\n  columns: [name, popularity]"), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if config := cmd.LoadConfig(configPath); len(config.UI.Columns) != 0 {
+		t.Errorf("Expected unknown column to fall back to defaults, got %v", config.UI.Columns)
+	}
+
+	if err := os.WriteFile(configPath, []byte("ui:\n  columns: [icon, name, description]"), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if config := cmd.LoadConfig(configPath); len(config.UI.Columns) != 3 {
+		t.Errorf("Expected columns to be loaded, got %v", config.UI.Columns)
+	}
+}
+
+func TestConfigPicker(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	configPath := filepath.Join(env.tmpDir, "picker.yml")
+	for picker, valid := range map[string]bool{"builtin": true, "fzf": true, "auto": true, "skim": false} {
+		if err := os.WriteFile(configPath, []byte("ui:\n  picker: "+picker), 0o644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+		if got := cmd.LoadConfig(configPath).UI.Picker == picker; got != valid {
+			t.Errorf("LoadConfig() kept picker %q = %v, want %v", picker, got, valid)
+		}
+	}
+}
+
+func TestConfigActions(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	configPath := filepath.Join(env.tmpDir, "actions.yml")
+	if err := os.WriteFile(configPath, []byte("ui:\n  actions:\n    ctrl-b: browse\n    ctrl-d: delete"), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if config := cmd.LoadConfig(configPath); config.UI.Actions != nil {
+		t.Errorf("Expected unknown action to fall back to defaults, got %v", config.UI.Actions)
+	}
+
+	if err := os.WriteFile(configPath, []byte("ui:\n  actions:\n    ctrl-r: browse"), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if config := cmd.LoadConfig(configPath); config.UI.Actions != nil {
+		t.Errorf("Expected an action on the reload key to be rejected, got %v", config.UI.Actions)
+	}
+
+	if err := os.WriteFile(configPath, []byte("ui:\n  actions:\n    ctrl-m: browse"), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if config := cmd.LoadConfig(configPath); config.UI.Actions != nil {
+		t.Errorf("Expected an action on the enter key's control code to be rejected, got %v", config.UI.Actions)
+	}
+
+	if err := os.WriteFile(configPath, []byte("ui:\n  actions:\n    ctrl-b: browse"), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if config := cmd.LoadConfig(configPath); config.UI.Actions["ctrl-b"] != cmd.ActionBrowse {
+		t.Errorf("Expected actions to be loaded, got %v", config.UI.Actions)
+	}
+}
+
+func TestConfigFzf(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	configPath := filepath.Join(env.tmpDir, "fzf.yml")
+	if err := os.WriteFile(configPath, []byte("ui:\n  fzf:\n    extra_args: [--delimiter=,]"), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if config := cmd.LoadConfig(configPath); config.UI.Fzf.ExtraArgs != nil {
+		t.Errorf("Expected reserved extra args to fall back to defaults, got %v", config.UI.Fzf.ExtraArgs)
+	}
+
+	for _, content := range []string{
+		"ui:\n  fzf:\n    binds:\n      ctrl-r: abort",
+		"ui:\n  actions:\n    ctrl-b: browse\n  fzf:\n    binds:\n      ctrl-b: abort",
+	} {
+		if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+		if config := cmd.LoadConfig(configPath); config.UI.Fzf.Binds != nil {
+			t.Errorf("Expected a bind on a picker key to fall back to defaults, got %v", config.UI.Fzf.Binds)
+		}
+	}
+
+	content := "ui:\n  fzf:\n    preview_window: down:40%\n    colors: dark\n    multi: false\n    binds:\n      ctrl-a: select-all\n    extra_args: [--cycle]"
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	fzf := cmd.LoadConfig(configPath).UI.Fzf
+	if fzf.PreviewWindow != "down:40%" || fzf.Colors != "dark" || fzf.Multi == nil || *fzf.Multi ||
+		fzf.Binds["ctrl-a"] != "select-all" || len(fzf.ExtraArgs) != 1 || fzf.ExtraArgs[0] != "--cycle" {
+		t.Errorf("Expected fzf settings to be loaded, got %+v", fzf)
+	}
+}
@@ -0,0 +1,117 @@
+package cmd_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func mockDiskFreeSpace(t *testing.T, free int64) {
+	t.Helper()
+	original := cmd.DiskFreeSpace
+	cmd.DiskFreeSpace = func(string) (int64, error) { return free, nil }
+	t.Cleanup(func() { cmd.DiskFreeSpace = original })
+}
+
+func createSizedRepos(sizesKB map[string]int) []cmd.Repo {
+	var repos []cmd.Repo
+	for _, name := range []string{"small-repo", "mono-repo", "existing-repo"} {
+		if size, ok := sizesKB[name]; ok {
+			repos = append(repos, cmd.Repo{Name: name, HTMLURL: "https://github.com/user/" + name, DiskUsage: size})
+		}
+	}
+	return repos
+}
+
+func TestEstimateCloneSize(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	cmd.SetConfig(cmd.Config{Repos: cmd.ReposConfig{ProjectsDir: "~/Projects"}})
+	createLocalClone(t, filepath.Join(env.tmpDir, "Projects", "existing-repo"))
+
+	repos := createSizedRepos(map[string]int{"small-repo": 1024, "mono-repo": 2048, "existing-repo": 4096})
+	if got, want := cmd.EstimateCloneSize(repos), int64(3*1024*1024); got != want {
+		t.Errorf("EstimateCloneSize() = %d, want %d", got, want)
+	}
+}
+
+func TestFindDiskSpaceShortfalls(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	cmd.SetConfig(cmd.Config{
+		Repos:       cmd.ReposConfig{ProjectsDir: "~/Projects"},
+		Performance: cmd.PerformanceConfig{DiskSpace: cmd.DiskSpaceConfig{Mode: cmd.DiskSpaceWarn, Margin: "1MB"}},
+	})
+	repos := createSizedRepos(map[string]int{"mono-repo": 2048})
+
+	mockDiskFreeSpace(t, 4*1024*1024)
+	if shortfalls := cmd.FindDiskSpaceShortfalls(repos); len(shortfalls) != 0 {
+		t.Errorf("expected no shortfalls, got %v", shortfalls)
+	}
+
+	mockDiskFreeSpace(t, 2*1024*1024)
+	shortfalls := cmd.FindDiskSpaceShortfalls(repos)
+	if len(shortfalls) != 1 || !strings.Contains(shortfalls[0], "2.0 MB") {
+		t.Errorf("expected one shortfall mentioning 2.0 MB, got %v", shortfalls)
+	}
+}
+
+func TestFindDiskSpaceShortfallsSharedFilesystem(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	cmd.SetConfig(cmd.Config{
+		Repos:       cmd.ReposConfig{ProjectsDir: "~/Projects", PerUserDir: true},
+		Performance: cmd.PerformanceConfig{DiskSpace: cmd.DiskSpaceConfig{Mode: cmd.DiskSpaceWarn, Margin: "0"}},
+	})
+	for _, owner := range []string{"acme", "widgets"} {
+		if err := os.MkdirAll(filepath.Join(env.tmpDir, "Projects", owner), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repos := []cmd.Repo{
+		{Name: "api", HTMLURL: "https://github.com/acme/api", Owner: cmd.Owner{Login: "acme"}, DiskUsage: 6 * 1024},
+		{Name: "web", HTMLURL: "https://github.com/widgets/web", Owner: cmd.Owner{Login: "widgets"}, DiskUsage: 6 * 1024},
+	}
+
+	mockDiskFreeSpace(t, 10*1024*1024)
+	shortfalls := cmd.FindDiskSpaceShortfalls(repos)
+	if len(shortfalls) != 1 || !strings.Contains(shortfalls[0], "12.0 MB") {
+		t.Errorf("expected one shortfall for both directories on the same filesystem, got %v", shortfalls)
+	}
+}
+
+func TestCloneReposDiskSpaceAbort(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recorder := recordExecCommands(t)
+	mockDiskFreeSpace(t, 1024)
+
+	for _, tt := range []struct {
+		mode     string
+		expected cmd.ResultStatus
+	}{
+		{cmd.DiskSpaceAbort, cmd.StatusFailed},
+		{cmd.DiskSpaceWarn, cmd.StatusCloned},
+		{cmd.DiskSpaceOff, cmd.StatusCloned},
+	} {
+		t.Run(tt.mode, func(t *testing.T) {
+			cmd.SetConfig(cmd.Config{
+				Repos:       cmd.ReposConfig{ProjectsDir: "~/Projects/" + tt.mode},
+				Performance: cmd.PerformanceConfig{DiskSpace: cmd.DiskSpaceConfig{Mode: tt.mode, Margin: "0"}},
+			})
+
+			results := cmd.CloneReposWithResults(context.Background(), createSizedRepos(map[string]int{"mono-repo": 4096}))
+			if results[0].Status != tt.expected {
+				t.Errorf("status = %s, want %s (err: %v)", results[0].Status, tt.expected, results[0].Err)
+			}
+		})
+	}
+
+	if !recorder.has("git", "clone") {
+		t.Error("expected warn and off modes to clone")
+	}
+}
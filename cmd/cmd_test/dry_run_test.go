@@ -0,0 +1,66 @@
+package cmd_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func enableDryRun(t *testing.T) {
+	t.Helper()
+	cmd.DryRun = true
+	t.Cleanup(func() { cmd.DryRun = false })
+}
+
+func TestCloneReposDryRun(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recorder := recordExecCommands(t)
+	enableDryRun(t)
+	cmd.SetConfig(cmd.Config{
+		Repos:        cmd.ReposConfig{ProjectsDir: "~/Projects", PerUserDir: true},
+		Integrations: cmd.IntegrationsConfig{Git: cmd.GitConfig{OnExisting: cmd.OnExistingSkip}},
+	})
+
+	existingPath := filepath.Join(env.tmpDir, "Projects", "user", "existing-repo")
+	createLocalClone(t, existingPath)
+
+	results := cmd.CloneReposWithResults(context.Background(), createSyncRepos("new-repo", "existing-repo"))
+
+	expected := map[string]cmd.ResultStatus{"new-repo": cmd.StatusPlanned, "existing-repo": cmd.StatusSkipped}
+	for _, result := range results {
+		if result.Status != expected[result.Repo.Name] {
+			t.Errorf("%s status = %s, want %s (err: %v)", result.Repo.Name, result.Status, expected[result.Repo.Name], result.Err)
+		}
+	}
+	if recorder.has("git", "clone") {
+		t.Error("dry run should not run git clone")
+	}
+	if _, err := os.Stat(filepath.Join(env.tmpDir, "Projects", "user", "new-repo")); !os.IsNotExist(err) {
+		t.Error("dry run should not create the target directory")
+	}
+	if code := results.ExitCode(); code != 0 {
+		t.Errorf("ExitCode() = %d, want 0", code)
+	}
+}
+
+func TestHandlePostCloneDryRun(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recorder := recordExecCommands(t)
+	enableDryRun(t)
+	cmd.SetConfig(cmd.Config{
+		Repos:        cmd.ReposConfig{ProjectsDir: "~/Projects"},
+		Integrations: cmd.IntegrationsConfig{PostClone: cmd.CommandConfig{Enabled: true, Command: "echo", Args: []string{"open"}}},
+	})
+
+	if err := cmd.HandlePostClone(createSyncRepos("planned-repo")); err != nil {
+		t.Fatalf("HandlePostClone() error = %v", err)
+	}
+	if recorder.has("echo") {
+		t.Error("dry run should not run the post-clone command")
+	}
+}
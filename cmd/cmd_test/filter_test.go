@@ -0,0 +1,110 @@
+package cmd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func createFilterRepos() []cmd.Repo {
+	now := time.Now()
+	return []cmd.Repo{
+		{
+			Name: "gh-repo-man", Description: "Manage repositories", Owner: cmd.Owner{Login: "acme"},
+			PrimaryLanguage: cmd.Language{Name: "Go"}, StargazerCount: 42, DiskUsage: 2048,
+			Topics:    []cmd.Topic{{Name: "cli"}},
+			UpdatedAt: now.AddDate(0, 0, -10), CreatedAt: time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Name: "old-tool", Owner: cmd.Owner{Login: "acme"}, PrimaryLanguage: cmd.Language{Name: "Go"},
+			StargazerCount: 5, IsArchived: true, UpdatedAt: now.AddDate(-2, 0, 0), CreatedAt: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Name: "site", Description: "Personal website", Owner: cmd.Owner{Login: "me"},
+			PrimaryLanguage: cmd.Language{Name: "TypeScript"}, StargazerCount: 11, IsPrivate: true, IsFork: true,
+			Topics:    []cmd.Topic{{Name: "web"}},
+			UpdatedAt: now.AddDate(0, -6, 0), CreatedAt: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func filterNames(t *testing.T, expr string) []string {
+	t.Helper()
+	match, err := cmd.ParseFilter(expr)
+	if err != nil {
+		t.Fatalf("ParseFilter(%q) error = %v", expr, err)
+	}
+	var names []string
+	for _, repo := range match.Filter(createFilterRepos()) {
+		names = append(names, repo.Name)
+	}
+	return names
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected []string
+	}{
+		{"", []string{"gh-repo-man", "old-tool", "site"}},
+		{"lang:go", []string{"gh-repo-man", "old-tool"}},
+		{"lang:go stars:>10", []string{"gh-repo-man"}},
+		{"stars:>=11", []string{"gh-repo-man", "site"}},
+		{"stars:5..11", []string{"old-tool", "site"}},
+		{"stars:<6", []string{"old-tool"}},
+		{"topic:cli OR topic:web", []string{"gh-repo-man", "site"}},
+		{"-is:archived", []string{"gh-repo-man", "site"}},
+		{"NOT is:archived AND owner:acme", []string{"gh-repo-man"}},
+		{"is:public", []string{"gh-repo-man", "old-tool"}},
+		{"is:fork", []string{"site"}},
+		{"updated:<90d", []string{"gh-repo-man"}},
+		{"updated:>1y", []string{"old-tool"}},
+		{"created:>2023-06-01", []string{"site"}},
+		{"created:2023-05-01", []string{"gh-repo-man"}},
+		{"owner:ac*", []string{"gh-repo-man", "old-tool"}},
+		{"owner:acm", nil},
+		{"name:repo", []string{"gh-repo-man"}},
+		{"name:*-*", []string{"gh-repo-man", "old-tool"}},
+		{"website", []string{"site"}},
+		{`"personal website"`, []string{"site"}},
+		{"size:>1MB", []string{"gh-repo-man"}},
+		{"owner:me OR (lang:go -is:archived)", []string{"gh-repo-man", "site"}},
+		{"-(lang:go OR is:private)", nil},
+		{"!(is:archived | is:fork)", []string{"gh-repo-man"}},
+		{"lang:go (stars:>10 OR is:archived) updated:<1y", []string{"gh-repo-man"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got := filterNames(t, tt.expr)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("filter %q = %v, want %v", tt.expr, got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("filter %q = %v, want %v", tt.expr, got, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	for _, expr := range []string{
+		"colour:red",
+		"stars:>many",
+		"is:secret",
+		"updated:<soon",
+		"(lang:go",
+		"lang:go)",
+		"lang:",
+		"OR lang:go",
+		"lang:go AND",
+		`name:"unterminated`,
+	} {
+		if _, err := cmd.ParseFilter(expr); err == nil {
+			t.Errorf("ParseFilter(%q) expected an error", expr)
+		}
+	}
+}
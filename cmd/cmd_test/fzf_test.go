@@ -0,0 +1,81 @@
+package cmd_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func TestBuildFzfArgsDefaults(t *testing.T) {
+	cmd.SetConfig(cmd.Config{})
+
+	args := cmd.BuildFzfArgs("", []string{"ctrl-o", "ctrl-s"})
+	for _, expected := range []string{"--multi", "--preview", "ctrl-o,ctrl-s"} {
+		if !slices.Contains(args, expected) {
+			t.Errorf("expected %q in fzf args, got %v", expected, args)
+		}
+	}
+	for _, unexpected := range []string{"--preview-window", "--color"} {
+		if slices.Contains(args, unexpected) {
+			t.Errorf("expected %s to be left to FZF_DEFAULT_OPTS, got %v", unexpected, args)
+		}
+	}
+}
+
+func TestBuildFzfArgsConfig(t *testing.T) {
+	multi := false
+	cmd.SetConfig(cmd.Config{UI: cmd.UIConfig{Fzf: cmd.FzfConfig{
+		ExtraArgs:     []string{"--layout=reverse", "--cycle"},
+		PreviewWindow: "down:40%",
+		Colors:        "dark,hl:#5f87af",
+		Binds:         map[string]string{"ctrl-j": "down", "ctrl-a": "select-all"},
+		Multi:         &multi,
+	}}})
+	defer cmd.SetConfig(cmd.Config{})
+
+	args := strings.Join(cmd.BuildFzfArgs("", nil), " ")
+	for _, expected := range []string{
+		"--no-multi",
+		"--preview-window down:40%",
+		"--color dark,hl:#5f87af",
+		"--bind ctrl-a:select-all --bind ctrl-j:down",
+	} {
+		if !strings.Contains(args, expected) {
+			t.Errorf("expected %q in fzf args, got %s", expected, args)
+		}
+	}
+	if !strings.HasSuffix(args, "--layout=reverse --cycle") {
+		t.Errorf("expected extra args last, got %s", args)
+	}
+	if strings.Contains(args, "--multi") || strings.Contains(args, "--expect") {
+		t.Errorf("expected no --multi or --expect, got %s", args)
+	}
+}
+
+func TestConfigFzfReservedOptions(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	configPath := filepath.Join(env.tmpDir, "fzf.yml")
+	for _, arg := range []string{"--delimiter=,", "--with-nth", "-d:", "-d :", "-d", "-fquery", " --expect=ctrl-a"} {
+		content := fmt.Sprintf("ui:\n  fzf:\n    extra_args: [%q]", arg)
+		if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+		if config := cmd.LoadConfig(configPath); config.UI.Fzf.ExtraArgs != nil {
+			t.Errorf("expected extra arg %q to be rejected, got %v", arg, config.UI.Fzf.ExtraArgs)
+		}
+	}
+
+	if err := os.WriteFile(configPath, []byte("ui:\n  fzf:\n    extra_args: [-e, --layout=reverse]"), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if config := cmd.LoadConfig(configPath); len(config.UI.Fzf.ExtraArgs) != 2 {
+		t.Errorf("expected other options to be accepted, got %v", config.UI.Fzf.ExtraArgs)
+	}
+}
@@ -0,0 +1,91 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		remote   string
+		expected cmd.RemoteRef
+		ok       bool
+	}{
+		{
+			name:     "https URL",
+			remote:   "https://github.com/user/repo",
+			expected: cmd.RemoteRef{Host: "github.com", Owner: "user", Name: "repo"},
+			ok:       true,
+		},
+		{
+			name:     "https URL with .git suffix",
+			remote:   "https://github.com/user/repo.git",
+			expected: cmd.RemoteRef{Host: "github.com", Owner: "user", Name: "repo"},
+			ok:       true,
+		},
+		{
+			name:     "scp-style SSH URL",
+			remote:   "git@github.com:user/repo.git",
+			expected: cmd.RemoteRef{Host: "github.com", Owner: "user", Name: "repo"},
+			ok:       true,
+		},
+		{
+			name:     "ssh URL with port",
+			remote:   "ssh://git@github.example.com:2222/org/tool.git",
+			expected: cmd.RemoteRef{Host: "github.example.com", Owner: "org", Name: "tool"},
+			ok:       true,
+		},
+		{
+			name:   "local path",
+			remote: "/srv/git/repo",
+			ok:     false,
+		},
+		{
+			name:   "missing repository name",
+			remote: "https://github.com/user",
+			ok:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, ok := cmd.ParseRemoteURL(tt.remote)
+			if ok != tt.ok {
+				t.Fatalf("ParseRemoteURL(%q) ok = %v, want %v", tt.remote, ok, tt.ok)
+			}
+			if ok && ref != tt.expected {
+				t.Errorf("ParseRemoteURL(%q) = %+v, want %+v", tt.remote, ref, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRemoteRefFullName(t *testing.T) {
+	ref := cmd.RemoteRef{Host: "github.com", Owner: "user", Name: "repo"}
+	if ref.FullName() != "user/repo" {
+		t.Errorf("FullName() = %q, want %q", ref.FullName(), "user/repo")
+	}
+}
+
+func TestRemoteMatchesRepo(t *testing.T) {
+	repo := cmd.Repo{Name: "Repo", HTMLURL: "https://github.com/User/Repo"}
+
+	tests := []struct {
+		remote   string
+		expected bool
+	}{
+		{"git@github.com:user/repo.git", true},
+		{"https://github.com/USER/REPO.git", true},
+		{"git@github.com:user/other.git", false},
+		{"git@gitlab.com:user/repo.git", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := cmd.RemoteMatchesRepo(tt.remote, repo); got != tt.expected {
+			t.Errorf("RemoteMatchesRepo(%q) = %v, want %v", tt.remote, got, tt.expected)
+		}
+	}
+}
@@ -398,3 +398,65 @@ func TestGetUserContext(t *testing.T) {
 		t.Errorf("GetUserContext(\"testuser\") = %q, want \"user 'testuser'\"", ctx)
 	}
 }
+
+func TestGetForkInfo(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+
+	t.Run("fork with parent", func(t *testing.T) {
+		info, err := cmd.GetForkInfo("user/forked-repo")
+		if err != nil {
+			t.Fatalf("GetForkInfo() returned error: %v", err)
+		}
+		if info.DefaultBranch != "main" {
+			t.Errorf("DefaultBranch = %q, want %q", info.DefaultBranch, "main")
+		}
+		if info.Parent == nil || info.Parent.FullName != "upstream/forked-repo" || info.Parent.DefaultBranch != "develop" {
+			t.Errorf("Parent = %+v, want upstream/forked-repo on develop", info.Parent)
+		}
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		if _, err := cmd.GetForkInfo("user/missing"); err == nil {
+			t.Error("GetForkInfo() should return an error for an unknown repository")
+		}
+	})
+}
+
+func TestGetForkDivergence(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+
+	repo := cmd.Repo{Name: "forked-repo", Owner: cmd.Owner{Login: "user"}, IsFork: true}
+	parent, divergence, err := cmd.GetForkDivergence(repo)
+	if err != nil {
+		t.Fatalf("GetForkDivergence() returned error: %v", err)
+	}
+	if parent.FullName != "upstream/forked-repo" {
+		t.Errorf("parent = %q, want %q", parent.FullName, "upstream/forked-repo")
+	}
+	if divergence.AheadBy != 2 || divergence.BehindBy != 5 {
+		t.Errorf("divergence = %+v, want 2 ahead and 5 behind", divergence)
+	}
+}
+
+func TestGetCachedForkDivergence(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+	recorder := recordExecCommands(t)
+
+	repo := cmd.Repo{Name: "forked-repo", Owner: cmd.Owner{Login: "user"}, IsFork: true}
+	for i := 0; i < 2; i++ {
+		parent, divergence, err := cmd.GetCachedForkDivergence(repo)
+		if err != nil {
+			t.Fatalf("GetCachedForkDivergence() returned error: %v", err)
+		}
+		if parent.FullName != "upstream/forked-repo" || divergence.AheadBy != 2 || divergence.BehindBy != 5 {
+			t.Errorf("GetCachedForkDivergence() = %+v, %+v", parent, divergence)
+		}
+	}
+
+	if len(recorder.commands) != 2 {
+		t.Errorf("expected the second lookup to come from the cache, got %v", recorder.commands)
+	}
+}
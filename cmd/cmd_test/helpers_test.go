@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -63,6 +67,10 @@ func TestHelperProcess(t *testing.T) {
 		handleGhCommand()
 	case "git":
 		handleGitCommand()
+	case "tmux":
+		handleTmuxCommand(os.Args[4:])
+	case "failing-hook":
+		os.Exit(1)
 	}
 	os.Exit(0)
 }
@@ -72,8 +80,7 @@ func handleGhCommand() {
 		hasUsername := false
 		for i := 6; i < len(os.Args); i++ {
 			arg := os.Args[i]
-			if arg != "--limit" && arg != "1000" && arg != "--json" &&
-				arg != "name,description,url,stargazerCount,forkCount,watchers,issues,owner,createdAt,updatedAt,diskUsage,homepageUrl,isFork,isArchived,isPrivate,isTemplate,repositoryTopics,primaryLanguage" {
+			if arg != "--limit" && arg != "1000" && arg != "--json" && arg != cmd.JSONFields {
 				hasUsername = true
 				break
 			}
@@ -97,6 +104,27 @@ func handleGhCommand() {
 			fmt.Fprint(os.Stderr, "Not Found")
 			os.Exit(1)
 		}
+	} else if os.Args[4] == "api" {
+		handleGhAPI(os.Args[5])
+	}
+}
+
+func handleGhAPI(endpoint string) {
+	if strings.HasPrefix(endpoint, "user/starred/") {
+		return
+	}
+	switch endpoint {
+	case "repos/user/forked-repo":
+		fmt.Fprint(os.Stdout, `{"default_branch":"main","parent":{"full_name":"upstream/forked-repo","html_url":"https://github.com/upstream/forked-repo","default_branch":"develop"}}`)
+	case "repos/upstream/forked-repo/compare/develop...user:main":
+		fmt.Fprint(os.Stdout, `{"ahead_by":2,"behind_by":5}`)
+	case "users/acme/starred?per_page=100":
+		fmt.Fprintf(os.Stdout, "[%s]\n[%s]\n", mockStarredRepoJSON, mockRepo1RESTJSON)
+	case "search/repositories?per_page=100&q=topic%3Acli+user%3Aacme":
+		fmt.Fprintf(os.Stdout, `{"total_count":1,"items":[%s]}`, mockStarredRepoJSON)
+	default:
+		fmt.Fprint(os.Stderr, "Not Found")
+		os.Exit(1)
 	}
 }
 
@@ -107,6 +135,78 @@ func handleGitCommand() {
 			os.Exit(1)
 		}
 		fmt.Fprintf(os.Stdout, "Cloning into '%s'...\n", os.Args[5])
+	} else if os.Args[4] == "-C" {
+		handleGitSubcommand(os.Args[5], os.Args[6:])
+	}
+}
+
+func handleGitSubcommand(repoPath string, args []string) {
+	if args[0] == "bundle" && strings.Contains(repoPath, "broken-bundle") {
+		fmt.Fprint(os.Stderr, "fatal: refusing to create empty bundle")
+		os.Exit(128)
+	}
+	if args[0] == "clone" && slices.Contains(args, "fail_clone_url") {
+		fmt.Fprint(os.Stderr, "mock clone error")
+		os.Exit(1)
+	}
+	switch strings.Join(args, " ") {
+	case "remote get-url origin":
+		if filepath.Base(repoPath) == "unrelated-repo" {
+			fmt.Fprint(os.Stdout, "git@github.com:someone/else.git")
+			return
+		}
+		fmt.Fprintf(os.Stdout, "git@github.com:user/%s.git", filepath.Base(repoPath))
+	case "symbolic-ref --short refs/remotes/origin/HEAD":
+		fmt.Fprint(os.Stdout, "origin/main")
+	case "rev-parse --abbrev-ref HEAD":
+		fmt.Fprint(os.Stdout, "main")
+	case "rev-parse --verify --quiet refs/heads/main":
+		if _, err := os.Stat(filepath.Join(repoPath, ".git", "merged")); err == nil {
+			fmt.Fprint(os.Stdout, "bbbbbbb")
+			return
+		}
+		fmt.Fprint(os.Stdout, "aaaaaaa")
+	case "merge --ff-only origin/main":
+		if filepath.Base(repoPath) == "behind-repo" {
+			_ = os.WriteFile(filepath.Join(repoPath, ".git", "merged"), nil, 0o644)
+		}
+	case "status --porcelain":
+		if filepath.Base(repoPath) == "dirty-repo" {
+			fmt.Fprint(os.Stdout, " M main.go")
+		}
+	case "stash list":
+		if filepath.Base(repoPath) == "stashed-repo" {
+			fmt.Fprint(os.Stdout, "stash@{0}: WIP on main: abc1234 Initial commit\nstash@{1}: WIP on main: abc1234 Initial commit")
+		}
+	case "log -1 --format=%h %s (%cr)":
+		fmt.Fprint(os.Stdout, "abc1234 Initial commit (2 days ago)")
+	case "rev-parse --abbrev-ref --symbolic-full-name @{upstream}":
+		if filepath.Base(repoPath) == "local-only-repo" {
+			fmt.Fprint(os.Stderr, "fatal: no upstream configured for branch 'main'")
+			os.Exit(128)
+		}
+		fmt.Fprint(os.Stdout, "origin/main")
+	case "rev-list --left-right --count HEAD...@{upstream}":
+		if filepath.Base(repoPath) == "ahead-repo" {
+			fmt.Fprint(os.Stdout, "3\t1")
+			return
+		}
+		fmt.Fprint(os.Stdout, "0\t0")
+	}
+}
+
+func handleTmuxCommand(args []string) {
+	switch args[0] {
+	case "has-session":
+		if !strings.Contains(args[len(args)-1], "existing") {
+			os.Exit(1)
+		}
+	case "list-windows":
+		fmt.Fprint(os.Stdout, "user/existing-window\t@9")
+	case "new-session", "new-window":
+		fmt.Fprint(os.Stdout, "@1 %1")
+	case "split-window":
+		fmt.Fprint(os.Stdout, "%2")
 	}
 }
 
@@ -155,3 +255,41 @@ func createTempConfigFile(t *testing.T, config cmd.Config) string {
 	t.Cleanup(func() { os.Remove(f.Name()) })
 	return f.Name()
 }
+
+type commandRecorder struct {
+	mu       sync.Mutex
+	commands [][]string
+}
+
+// recordExecCommands routes cmd.ExecCommand through the helper process and records every invocation
+func recordExecCommands(t *testing.T) *commandRecorder {
+	recorder := &commandRecorder{}
+	originalExecCmd := cmd.ExecCommand
+	cmd.ExecCommand = func(command string, args ...string) *exec.Cmd {
+		recorder.mu.Lock()
+		recorder.commands = append(recorder.commands, append([]string{command}, args...))
+		recorder.mu.Unlock()
+
+		cs := []string{"-test.run=TestHelperProcess", "--", command}
+		cs = append(cs, args...)
+		cmd := exec.Command(os.Args[0], cs...)
+		cmd.Env = []string{"GO_WANT_HELPER_PROCESS=1"}
+		return cmd
+	}
+	t.Cleanup(func() { cmd.ExecCommand = originalExecCmd })
+	return recorder
+}
+
+// has reports whether a command containing all the given arguments in order was executed
+func (r *commandRecorder) has(args ...string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, command := range r.commands {
+		for start := 0; start+len(args) <= len(command); start++ {
+			if reflect.DeepEqual(command[start:start+len(args)], args) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package cmd_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func createHookRepos() []cmd.Repo {
+	return []cmd.Repo{
+		{Name: "go-repo", HTMLURL: "https://github.com/user/go-repo", Owner: cmd.Owner{Login: "user"}, PrimaryLanguage: cmd.Language{Name: "Go"}},
+		{Name: "js-repo", HTMLURL: "https://github.com/user/js-repo", Owner: cmd.Owner{Login: "user"}, PrimaryLanguage: cmd.Language{Name: "JavaScript"}},
+	}
+}
+
+func TestExpandHookTemplate(t *testing.T) {
+	values := map[string]string{"path": "/src/repo", "name": "repo", "owner": "user", "language": "Go", "url": "https://github.com/user/repo"}
+	got := cmd.ExpandHookTemplate("{owner}/{name} ({language}) at {path} from {url}", values)
+	expected := "user/repo (Go) at /src/repo from https://github.com/user/repo"
+	if got != expected {
+		t.Errorf("ExpandHookTemplate() = %q, want %q", got, expected)
+	}
+}
+
+func TestRunHooks(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recorder := recordExecCommands(t)
+	cmd.SetConfig(cmd.Config{Repos: cmd.ReposConfig{ProjectsDir: "~/Projects"}})
+
+	repos := createHookRepos()
+	for _, repo := range repos {
+		createLocalClone(t, filepath.Join(env.tmpDir, "Projects", repo.Name))
+	}
+	goPath := filepath.Join(env.tmpDir, "Projects", "go-repo")
+	jsPath := filepath.Join(env.tmpDir, "Projects", "js-repo")
+
+	hooks := []cmd.HookConfig{
+		{Name: "identity", Command: "git", Args: []string{"-C", "{path}", "config", "user.name", "{owner}"}},
+		{Name: "deps", Command: "go", Args: []string{"mod", "download"}, When: cmd.RuleMatch{Language: "go"}},
+		{Name: "tmux", Command: "tmux-open", Args: []string{"{paths}"}, Once: true},
+	}
+
+	remaining, err := cmd.RunHooks(repos, hooks)
+	if err != nil {
+		t.Fatalf("RunHooks() error = %v", err)
+	}
+	if len(remaining) != 2 {
+		t.Errorf("expected both repos to remain, got %d", len(remaining))
+	}
+
+	for _, expected := range [][]string{
+		{"git", "-C", goPath, "config", "user.name", "user"},
+		{"git", "-C", jsPath, "config", "user.name", "user"},
+		{"go", "mod", "download"},
+		{"tmux-open", goPath, jsPath},
+	} {
+		if !recorder.has(expected...) {
+			t.Errorf("expected command %v, got %v", expected, recorder.commands)
+		}
+	}
+
+	goRuns := 0
+	for _, command := range recorder.commands {
+		if reflect.DeepEqual(command, []string{"go", "mod", "download"}) {
+			goRuns++
+		}
+	}
+	if goRuns != 1 {
+		t.Errorf("expected the go hook to run only for the Go repository, ran %d times", goRuns)
+	}
+}
+
+func TestRunHooksFailurePolicies(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	cmd.SetConfig(cmd.Config{Repos: cmd.ReposConfig{ProjectsDir: "~/Projects"}})
+
+	repos := createHookRepos()
+	for _, repo := range repos {
+		createLocalClone(t, filepath.Join(env.tmpDir, "Projects", repo.Name))
+	}
+
+	tests := []struct {
+		policy        string
+		wantErr       bool
+		wantRemaining int
+		wantFollowUp  bool
+	}{
+		{cmd.HookFailureContinue, false, 2, true},
+		{cmd.HookFailureStop, false, 1, true},
+		{cmd.HookFailureAbort, true, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			recorder := recordExecCommands(t)
+			hooks := []cmd.HookConfig{
+				{Command: "failing-hook", When: cmd.RuleMatch{Language: "Go"}, OnFailure: tt.policy},
+				{Command: "follow-up", Args: []string{"{name}"}},
+			}
+
+			remaining, err := cmd.RunHooks(repos, hooks)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunHooks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(remaining) != tt.wantRemaining {
+				t.Errorf("remaining = %d, want %d", len(remaining), tt.wantRemaining)
+			}
+			if got := recorder.has("follow-up", "js-repo"); got != tt.wantFollowUp {
+				t.Errorf("follow-up hook for js-repo ran = %v, want %v", got, tt.wantFollowUp)
+			}
+			if tt.policy == cmd.HookFailureStop && recorder.has("follow-up", "go-repo") {
+				t.Error("stop policy should skip later hooks for the failing repository")
+			}
+		})
+	}
+}
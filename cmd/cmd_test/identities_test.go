@@ -0,0 +1,135 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func createIdentities() []cmd.IdentityConfig {
+	return []cmd.IdentityConfig{
+		{Owners: []string{"work-*", "user"}, Name: "Work Me", Email: "me@work.example", SigningKey: "ABC123", SSHKey: "/keys/work"},
+		{Owners: []string{"*"}, Email: "me@home.example"},
+	}
+}
+
+func TestResolveIdentity(t *testing.T) {
+	cmd.SetConfig(cmd.Config{Identities: createIdentities()})
+
+	tests := []struct {
+		owner    string
+		expected string
+	}{
+		{"work-org", "me@work.example"},
+		{"USER", "me@work.example"},
+		{"someone", "me@home.example"},
+	}
+	for _, tt := range tests {
+		identity, ok := cmd.ResolveIdentity(tt.owner)
+		if !ok || identity.Email != tt.expected {
+			t.Errorf("ResolveIdentity(%q) = %+v, %v, want email %s", tt.owner, identity, ok, tt.expected)
+		}
+	}
+
+	cmd.SetConfig(cmd.Config{Identities: createIdentities()[:1]})
+	if _, ok := cmd.ResolveIdentity("someone"); ok {
+		t.Error("expected no identity for an unmatched owner")
+	}
+}
+
+func TestIdentityGitConfig(t *testing.T) {
+	got := cmd.IdentityGitConfig(createIdentities()[0])
+	expected := [][2]string{
+		{"user.name", "Work Me"},
+		{"user.email", "me@work.example"},
+		{"user.signingkey", "ABC123"},
+		{"commit.gpgsign", "true"},
+		{"core.sshCommand", "ssh -i /keys/work -o IdentitiesOnly=yes"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("IdentityGitConfig() = %v, want %v", got, expected)
+	}
+
+	got = cmd.IdentityGitConfig(cmd.IdentityConfig{SSHKey: "/keys/it's work"})
+	if want := `ssh -i '/keys/it'\''s work' -o IdentitiesOnly=yes`; len(got) != 1 || got[0][1] != want {
+		t.Errorf("IdentityGitConfig() = %v, want a quoted key path %s", got, want)
+	}
+}
+
+func TestCloneReposAppliesIdentity(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recorder := recordExecCommands(t)
+	cmd.SetConfig(cmd.Config{
+		Repos:      cmd.ReposConfig{ProjectsDir: "~/Projects"},
+		Identities: createIdentities(),
+	})
+
+	results := cmd.CloneReposWithResults(context.Background(), createSyncRepos("work-repo"))
+	if results.ExitCode() != 0 {
+		t.Fatalf("expected clone to succeed, got %v", results.Err())
+	}
+
+	repoPath := filepath.Join(env.tmpDir, "Projects", "work-repo")
+	for _, expected := range [][]string{
+		{"git", "-c", "core.sshCommand=ssh -i /keys/work -o IdentitiesOnly=yes", "clone"},
+		{"git", "-C", repoPath, "config", "--local", "user.name", "Work Me"},
+		{"git", "-C", repoPath, "config", "--local", "user.email", "me@work.example"},
+		{"git", "-C", repoPath, "config", "--local", "core.sshCommand", "ssh -i /keys/work -o IdentitiesOnly=yes"},
+	} {
+		if !recorder.has(expected...) {
+			t.Errorf("expected command %v, got %v", expected, recorder.commands)
+		}
+	}
+}
+
+func TestApplyIdentities(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+	recorder := recordExecCommands(t)
+	cmd.SetConfig(cmd.Config{
+		Repos:      cmd.ReposConfig{ProjectsDir: "~/Projects"},
+		Identities: createIdentities()[:1],
+	})
+
+	projects := filepath.Join(ts.env.tmpDir, "Projects")
+	workPath := filepath.Join(projects, "work-repo")
+	otherPath := filepath.Join(projects, "unrelated-repo")
+	for _, path := range []string{workPath, otherPath} {
+		createLocalClone(t, path)
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		results := cmd.ApplyIdentities(context.Background(), []string{workPath, otherPath}, true)
+		if results[0].Identity == nil || results[1].Identity != nil {
+			t.Errorf("unexpected dry run results: %+v", results)
+		}
+		if recorder.has("config", "--local") {
+			t.Error("expected no config changes in dry run")
+		}
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		results := cmd.ApplyIdentities(context.Background(), []string{workPath, otherPath}, false)
+		if !recorder.has("git", "-C", workPath, "config", "--local", "user.email", "me@work.example") {
+			t.Errorf("expected identity applied to work-repo, got %v", recorder.commands)
+		}
+		if recorder.has("git", "-C", otherPath, "config", "--local") {
+			t.Error("expected no identity applied to a clone of another owner")
+		}
+
+		var buf bytes.Buffer
+		cmd.PrintIdentityResults(&buf, results, false)
+		if !strings.Contains(buf.String(), "Applied Work Me <me@work.example> to work-repo") {
+			t.Errorf("unexpected output:\n%s", buf.String())
+		}
+		if !strings.Contains(buf.String(), "2 clones: 1 with identity, 1 without, 0 failed") {
+			t.Errorf("unexpected summary:\n%s", buf.String())
+		}
+	})
+}
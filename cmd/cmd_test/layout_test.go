@@ -0,0 +1,86 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func TestRenderLayout(t *testing.T) {
+	repo := cmd.Repo{
+		Name:            "dotfiles",
+		HTMLURL:         "https://github.example.com/octo/dotfiles",
+		Owner:           cmd.Owner{Login: "octo"},
+		PrimaryLanguage: cmd.Language{Name: "Shell"},
+		Topics:          []cmd.Topic{{Name: "config"}},
+	}
+
+	tests := []struct {
+		layout   string
+		repo     cmd.Repo
+		expected string
+	}{
+		{"{host}/{owner}/{name}", repo, filepath.Join("github.example.com", "octo", "dotfiles")},
+		{"{language}/{name}", repo, filepath.Join("Shell", "dotfiles")},
+		{"{owner}/{topic0}/{name}", repo, filepath.Join("octo", "config", "dotfiles")},
+		{"{owner}/{topic1}/{name}", repo, filepath.Join("octo", "none", "dotfiles")},
+		{"{host}/{name}", cmd.Repo{Name: "bare"}, filepath.Join("github.com", "bare")},
+		{"{language}/{name}", cmd.Repo{Name: "x", PrimaryLanguage: cmd.Language{Name: "a/b"}}, filepath.Join("a-b", "x")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			if got := cmd.RenderLayout(tt.layout, tt.repo); got != tt.expected {
+				t.Errorf("RenderLayout(%q) = %q, want %q", tt.layout, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetRepoPathWithLayout(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	cmd.SetConfig(cmd.Config{Repos: cmd.ReposConfig{ProjectsDir: "~/src", PerUserDir: true, Layout: "{host}/{owner}/{name}"}})
+
+	path, err := cmd.GetRepoPath(cmd.Repo{Name: "repo", HTMLURL: "https://github.com/user/repo", Owner: cmd.Owner{Login: "user"}})
+	if err != nil {
+		t.Fatalf("GetRepoPath() error = %v", err)
+	}
+	expected := filepath.Join(env.tmpDir, "src", "github.com", "user", "repo")
+	if path != expected {
+		t.Errorf("GetRepoPath() = %s, want %s", path, expected)
+	}
+}
+
+func TestConfigLayoutValidation(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	tests := []struct {
+		layout   string
+		expected string
+	}{
+		{"{host}/{owner}/{name}", "{host}/{owner}/{name}"},
+		{"{owner}/{topic0}/{name}", "{owner}/{topic0}/{name}"},
+		{"{owner}", ""},
+		{"{owner}/{stars}/{name}", ""},
+		{"../{name}", ""},
+		{"/abs/{name}", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			configPath := filepath.Join(env.tmpDir, "layout.yml")
+			if err := os.WriteFile(configPath, []byte("repos:\n  layout: \""+tt.layout+"\"\n"), 0o644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			if got := cmd.LoadConfig(configPath).Repos.Layout; got != tt.expected {
+				t.Errorf("Layout = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package cmd_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func TestFormatPickerLine(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	cmd.SetConfig(cmd.Config{Repos: cmd.ReposConfig{ProjectsDir: "~/Projects"}})
+	createLocalClone(t, filepath.Join(env.tmpDir, "Projects", "cloned-repo"))
+
+	cloned := cmd.FormatPickerLine(cmd.Repo{Name: "cloned-repo", Owner: cmd.Owner{Login: "user"}})
+	key, visible, _ := strings.Cut(cloned, "\t")
+	if key != "user/cloned-repo" || !strings.HasPrefix(visible, cmd.GetIcon("cloned")) || !strings.Contains(visible, "cloned-repo") {
+		t.Errorf("unexpected cloned line %q", cloned)
+	}
+
+	notCloned := cmd.FormatPickerLine(cmd.Repo{Name: "remote-repo", Owner: cmd.Owner{Login: "user"}})
+	_, visible, _ = strings.Cut(notCloned, "\t")
+	if strings.Contains(visible, cmd.GetIcon("cloned")) || !strings.Contains(visible, "remote-repo") {
+		t.Errorf("unexpected line for a repository that is not cloned %q", notCloned)
+	}
+
+	for _, line := range []string{cloned, notCloned, "plain-name"} {
+		want := strings.TrimSpace(strings.Split(line, "\t")[0])
+		if got := cmd.ParsePickerLine(line); got != want {
+			t.Errorf("ParsePickerLine(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
+
+func TestFormatPickerLinesColumns(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	cmd.SetConfig(cmd.Config{UI: cmd.UIConfig{Columns: []string{"name", "stars", "pushed", "badges"}}})
+
+	now := time.Now()
+	repos := []cmd.Repo{
+		{Name: "gh-repo-man", Owner: cmd.Owner{Login: "user"}, StargazerCount: 1234, PushedAt: now.Add(-3 * 24 * time.Hour), IsFork: true, IsArchived: true},
+		{Name: "dots", Owner: cmd.Owner{Login: "user"}, StargazerCount: 7, PushedAt: now.Add(-2 * time.Hour)},
+	}
+	star := cmd.GetIcon("star")
+	expected := []string{
+		"user/gh-repo-man\tgh-repo-man  " + star + "1.2k  3d ago  " + cmd.GetIcon("fork") + strings.TrimSpace(cmd.GetIcon("archived")),
+		"user/dots\tdots         " + star + "   7  2h ago",
+	}
+	if got := cmd.FormatPickerLines(repos); !reflect.DeepEqual(got, expected) {
+		t.Errorf("FormatPickerLines() =\n%q\nwant\n%q", got, expected)
+	}
+
+	repos[1].Owner.Login = "acme"
+	if got := cmd.FormatPickerLines(repos); !strings.HasPrefix(got[1], "acme/dots\tacme/dots ") {
+		t.Errorf("expected owner/name with repositories of several owners, got %q", got[1])
+	}
+}
+
+func TestFormatCountAndAge(t *testing.T) {
+	for n, expected := range map[int]string{0: "0", 999: "999", 1000: "1k", 1234: "1.2k", 2_500_000: "2.5m"} {
+		if got := cmd.FormatCount(n); got != expected {
+			t.Errorf("FormatCount(%d) = %q, want %q", n, got, expected)
+		}
+	}
+
+	now := time.Now()
+	for _, tt := range []struct {
+		at       time.Time
+		expected string
+	}{
+		{time.Time{}, ""},
+		{now.Add(-5 * time.Minute), "5m ago"},
+		{now.Add(-50 * 24 * time.Hour), "1mo ago"},
+		{now.Add(-800 * 24 * time.Hour), "2y ago"},
+	} {
+		if got := cmd.FormatAge(tt.at); got != tt.expected {
+			t.Errorf("FormatAge(%v) = %q, want %q", tt.at, got, tt.expected)
+		}
+	}
+}
+
+func TestSelectPickedRepos(t *testing.T) {
+	repos := []cmd.Repo{
+		{Name: "tool", Owner: cmd.Owner{Login: "user"}},
+		{Name: "tool", Owner: cmd.Owner{Login: "acme"}},
+		{Name: "dots", Owner: cmd.Owner{Login: "user"}},
+	}
+
+	picked := cmd.SelectPickedRepos(repos, []string{"user/dots", "acme/tool", "", "user/missing"})
+	if len(picked) != 2 || picked[0].Name != "dots" || picked[1].Owner.Login != "acme" {
+		t.Errorf("SelectPickedRepos() = %+v, want user/dots and acme/tool", picked)
+	}
+}
+
+func TestFilterRepositoriesByCloneState(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	cmd.SetConfig(cmd.Config{Repos: cmd.ReposConfig{ProjectsDir: "~/Projects"}})
+	createLocalClone(t, filepath.Join(env.tmpDir, "Projects", "cloned-repo"))
+
+	repos := createSyncRepos("cloned-repo", "remote-repo")
+	tests := []struct {
+		state    string
+		expected []string
+	}{
+		{"", []string{"cloned-repo", "remote-repo"}},
+		{cmd.CloneStateCloned, []string{"cloned-repo"}},
+		{cmd.CloneStateNotCloned, []string{"remote-repo"}},
+	}
+	for _, tt := range tests {
+		if got := repoNames(cmd.FilterRepositories(repos, "", "", tt.state)); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("FilterRepositories(%q) = %v, want %v", tt.state, got, tt.expected)
+		}
+	}
+}
+
+func TestBuildRepoPreviewShowsLocalState(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+	cmd.SetConfig(cmd.Config{Repos: cmd.ReposConfig{ProjectsDir: "~/Projects"}})
+	createLocalClone(t, filepath.Join(ts.env.tmpDir, "Projects", "dirty-repo"))
+
+	preview := cmd.BuildRepoPreview(cmd.Repo{Name: "dirty-repo", Owner: cmd.Owner{Login: "user"}})
+	for _, expected := range []string{"Cloned: ", "Branch: main (1 uncommitted changes)", "Last local commit: abc1234 Initial commit (2 days ago)"} {
+		if !strings.Contains(preview, expected) {
+			t.Errorf("expected preview to contain %q, got:\n%s", expected, preview)
+		}
+	}
+
+	remote := cmd.BuildRepoPreview(cmd.Repo{Name: "remote-repo", Owner: cmd.Owner{Login: "user"}})
+	if strings.Contains(remote, "Cloned: ") {
+		t.Errorf("expected no local state for a repository that is not cloned, got:\n%s", remote)
+	}
+}
+
+func TestBuildReloadCommandCloneState(t *testing.T) {
+	cmd.NotClonedOnly = true
+	defer func() { cmd.NotClonedOnly = false }()
+
+	if reloadCmd := cmd.BuildReloadCommand(""); !strings.Contains(reloadCmd, "--not-cloned") {
+		t.Errorf("expected reload command to contain --not-cloned, got: %s", reloadCmd)
+	}
+}
@@ -281,3 +281,49 @@ func TestHandlePostCloneWithExistingRepos(t *testing.T) {
 		}
 	}
 }
+
+func TestHandlePostCloneRuleOverride(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+
+	originalPath := os.Getenv("PATH")
+	defer os.Setenv("PATH", originalPath)
+	binDir := t.TempDir()
+	for _, name := range []string{"tea", "code"} {
+		if err := os.WriteFile(filepath.Join(binDir, name), []byte("#!/bin/sh\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.Setenv("PATH", binDir+string(os.PathListSeparator)+originalPath)
+
+	recorder := recordExecCommands(t)
+	cmd.SetConfig(cmd.Config{
+		Repos: cmd.ReposConfig{ProjectsDir: "~/Projects", PerUserDir: true},
+		Integrations: cmd.IntegrationsConfig{
+			PostClone: cmd.CommandConfig{Enabled: true, Command: "tea"},
+			Git: cmd.GitConfig{Rules: []cmd.CloneRule{
+				{
+					Match:       cmd.RuleMatch{Owner: "work-org"},
+					ProjectsDir: "~/work",
+					PostClone:   &cmd.CommandConfig{Enabled: true, Command: "code", Args: []string{"--new-window"}},
+				},
+			}},
+		},
+	})
+
+	repos := []cmd.Repo{
+		{Name: "personal", Owner: cmd.Owner{Login: "me"}},
+		{Name: "service", Owner: cmd.Owner{Login: "work-org"}},
+	}
+
+	if err := cmd.HandlePostClone(repos); err != nil {
+		t.Fatalf("HandlePostClone() returned error: %v", err)
+	}
+
+	if !recorder.has("tea", filepath.Join(env.tmpDir, "Projects", "me", "personal")) {
+		t.Errorf("expected default command for personal repo, got %v", recorder.commands)
+	}
+	if !recorder.has("code", "--new-window", filepath.Join(env.tmpDir, "work", "work-org", "service")) {
+		t.Errorf("expected rule command for work repo, got %v", recorder.commands)
+	}
+}
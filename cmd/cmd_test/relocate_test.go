@@ -0,0 +1,109 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func TestPlanAndApplyRelocations(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+
+	projects := filepath.Join(ts.env.tmpDir, "Projects")
+	oldPath := filepath.Join(projects, "user", "moving-repo")
+	placedPath := filepath.Join(projects, "github.com", "user", "placed-repo")
+	unrelatedPath := filepath.Join(projects, "user", "unrelated-repo")
+	for _, path := range []string{oldPath, placedPath, unrelatedPath} {
+		createLocalClone(t, path)
+	}
+	createLocalClone(t, filepath.Join(projects, "github.com", "someone", "else"))
+
+	cmd.SetConfig(cmd.Config{Repos: cmd.ReposConfig{ProjectsDir: "~/Projects", Layout: "{host}/{owner}/{name}"}})
+
+	paths := []string{oldPath, placedPath, unrelatedPath}
+	relocations := cmd.PlanRelocations(context.Background(), paths, createSyncRepos("moving-repo", "placed-repo"))
+
+	expected := []struct {
+		to     string
+		reason string
+	}{
+		{filepath.Join(projects, "github.com", "user", "moving-repo"), ""},
+		{placedPath, ""},
+		{filepath.Join(projects, "github.com", "someone", "else"), "target already exists"},
+	}
+	for i, relocation := range relocations {
+		if relocation.To != expected[i].to || relocation.Reason != expected[i].reason {
+			t.Errorf("relocation[%d] = %+v, want to %s reason %q", i, relocation, expected[i].to, expected[i].reason)
+		}
+	}
+
+	relocations = cmd.ApplyRelocations(relocations)
+	if !isDir(filepath.Join(projects, "github.com", "user", "moving-repo", ".git")) {
+		t.Error("moving-repo was not moved into the layout")
+	}
+	if isDir(oldPath) {
+		t.Error("moving-repo still exists at its old path")
+	}
+	if !isDir(unrelatedPath) {
+		t.Error("skipped clone should not be moved")
+	}
+
+	var buf bytes.Buffer
+	cmd.PrintRelocations(&buf, relocations, false)
+	if !strings.Contains(buf.String(), "3 clones: 1 moved, 1 already in place, 1 skipped") {
+		t.Errorf("unexpected relocation summary:\n%s", buf.String())
+	}
+}
+
+func TestApplyRelocationsKeepsProjectsRoot(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+
+	oldRoot := filepath.Join(ts.env.tmpDir, "old")
+	newRoot := filepath.Join(ts.env.tmpDir, "new")
+	oldPath := filepath.Join(oldRoot, "user", "moving-repo")
+	createLocalClone(t, oldPath)
+	cmd.SetConfig(cmd.Config{
+		Repos: cmd.ReposConfig{ProjectsDir: oldRoot + string(filepath.Separator), PerUserDir: true},
+		Integrations: cmd.IntegrationsConfig{Git: cmd.GitConfig{Rules: []cmd.CloneRule{
+			{Match: cmd.RuleMatch{Owner: "user"}, ProjectsDir: newRoot},
+		}}},
+	})
+
+	relocations := cmd.PlanRelocations(context.Background(), []string{oldPath}, createSyncRepos("moving-repo"))
+	relocations = cmd.ApplyRelocations(relocations)
+	if relocations[0].Err != nil || !isDir(filepath.Join(newRoot, "user", "moving-repo")) {
+		t.Fatalf("expected moving-repo to move under %s, got %+v", newRoot, relocations[0])
+	}
+	if isDir(filepath.Join(oldRoot, "user")) {
+		t.Error("expected the emptied owner directory to be removed")
+	}
+	if !isDir(oldRoot) {
+		t.Error("expected the projects root to be kept even when written with a trailing separator")
+	}
+}
+
+func TestPlanRelocationsRequiresMetadata(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+
+	path := filepath.Join(ts.env.tmpDir, "Projects", "user", "unknown-repo")
+	createLocalClone(t, path)
+	cmd.SetConfig(cmd.Config{Repos: cmd.ReposConfig{ProjectsDir: "~/Projects", Layout: "{language}/{name}"}})
+
+	relocations := cmd.PlanRelocations(context.Background(), []string{path}, nil)
+	if len(relocations) != 1 || relocations[0].NeedsMove() || !strings.Contains(relocations[0].Reason, "unknown repository") {
+		t.Errorf("PlanRelocations() = %+v, want unknown repository skip", relocations)
+	}
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -195,55 +195,96 @@ func TestFilterRepositories(t *testing.T) {
 	repos := createTestReposForFilter()
 
 	t.Run("filter by forked type", func(t *testing.T) {
-		filtered := cmd.FilterRepositories(repos, "forked", "")
+		filtered := cmd.FilterRepositories(repos, "forked", "", "")
 		if len(filtered) != 1 || filtered[0].Name != "repo2" {
 			t.Errorf("Expected 1 forked repo (repo2), got %d repos", len(filtered))
 		}
 	})
 
 	t.Run("filter by archived type", func(t *testing.T) {
-		filtered := cmd.FilterRepositories(repos, "archived", "")
+		filtered := cmd.FilterRepositories(repos, "archived", "", "")
 		if len(filtered) != 1 || filtered[0].Name != "repo3" {
 			t.Errorf("Expected 1 archived repo (repo3), got %d repos", len(filtered))
 		}
 	})
 
 	t.Run("filter by private type", func(t *testing.T) {
-		filtered := cmd.FilterRepositories(repos, "private", "")
+		filtered := cmd.FilterRepositories(repos, "private", "", "")
 		if len(filtered) != 1 || filtered[0].Name != "repo4" {
 			t.Errorf("Expected 1 private repo (repo4), got %d repos", len(filtered))
 		}
 	})
 
 	t.Run("filter by template type", func(t *testing.T) {
-		filtered := cmd.FilterRepositories(repos, "template", "")
+		filtered := cmd.FilterRepositories(repos, "template", "", "")
 		if len(filtered) != 1 || filtered[0].Name != "repo5" {
 			t.Errorf("Expected 1 template repo (repo5), got %d repos", len(filtered))
 		}
 	})
 
 	t.Run("filter by language", func(t *testing.T) {
-		filtered := cmd.FilterRepositories(repos, "", "Go")
+		filtered := cmd.FilterRepositories(repos, "", "Go", "")
 		if len(filtered) != 2 {
 			t.Errorf("Expected 2 Go repos, got %d repos", len(filtered))
 		}
 	})
 
 	t.Run("filter by type and language", func(t *testing.T) {
-		filtered := cmd.FilterRepositories(repos, "private", "Go")
+		filtered := cmd.FilterRepositories(repos, "private", "Go", "")
 		if len(filtered) != 1 || filtered[0].Name != "repo4" {
 			t.Errorf("Expected 1 private Go repo (repo4), got %d repos", len(filtered))
 		}
 	})
 
 	t.Run("no filters", func(t *testing.T) {
-		filtered := cmd.FilterRepositories(repos, "", "")
+		filtered := cmd.FilterRepositories(repos, "", "", "")
 		if len(filtered) != len(repos) {
 			t.Errorf("Expected all %d repos, got %d repos", len(repos), len(filtered))
 		}
 	})
 }
 
+func TestFilterRepositoriesMultipleTypes(t *testing.T) {
+	repos := createTestReposForFilter()
+	repos = append(repos, cmd.Repo{Name: "repo6", IsPrivate: true, Visibility: "INTERNAL"})
+
+	tests := []struct {
+		repoType string
+		expected []string
+	}{
+		{"source", []string{"repo1", "repo3", "repo4", "repo5", "repo6"}},
+		{"source,!archived", []string{"repo1", "repo4", "repo5", "repo6"}},
+		{"!forked,!archived", []string{"repo1", "repo4", "repo5", "repo6"}},
+		{"private,template", []string{"repo4", "repo5"}},
+		{"public,-template", []string{"repo1", "repo2", "repo3"}},
+		{"internal", []string{"repo6"}},
+		{" Archived ", []string{"repo3"}},
+		{"bogus", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repoType, func(t *testing.T) {
+			var names []string
+			for _, repo := range cmd.FilterRepositories(repos, tt.repoType, "", "") {
+				names = append(names, repo.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("FilterRepositories(%q) = %v, want %v", tt.repoType, names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseRepoTypesRejectsUnknownTypes(t *testing.T) {
+	_, err := cmd.ParseRepoTypes("source,!archivd")
+	if err == nil {
+		t.Fatal("expected an error for an unknown type")
+	}
+	if !strings.Contains(err.Error(), `"archivd"`) || !strings.Contains(err.Error(), "supported: archived, forked") {
+		t.Errorf("expected a helpful error, got %v", err)
+	}
+}
+
 func TestSortRepositories(t *testing.T) {
 	repos := createTestReposForFilter()
 
@@ -323,3 +364,116 @@ func TestSortRepositories(t *testing.T) {
 		}
 	})
 }
+
+func repoNames(repos []cmd.Repo) []string {
+	names := make([]string, len(repos))
+	for i, repo := range repos {
+		names[i] = repo.Name
+	}
+	return names
+}
+
+func TestSortRepositoriesMultipleKeys(t *testing.T) {
+	repos := []cmd.Repo{
+		{Name: "b", Owner: cmd.Owner{Login: "x"}, StargazerCount: 5, PrimaryLanguage: cmd.Language{Name: "Go"}},
+		{Name: "a", Owner: cmd.Owner{Login: "x"}, StargazerCount: 5, PrimaryLanguage: cmd.Language{Name: "Go"}},
+		{Name: "c", Owner: cmd.Owner{Login: "x"}, StargazerCount: 9, PrimaryLanguage: cmd.Language{Name: "Go"}},
+		{Name: "d", Owner: cmd.Owner{Login: "x"}, StargazerCount: 1, PrimaryLanguage: cmd.Language{Name: "C"}},
+		{Name: "a", Owner: cmd.Owner{Login: "w"}, StargazerCount: 5, PrimaryLanguage: cmd.Language{Name: "Go"}},
+	}
+
+	tests := []struct {
+		sortBy   string
+		expected []string
+	}{
+		{"language,-stars,name", []string{"d", "c", "a", "a", "b"}},
+		{"+stars", []string{"d", "a", "a", "b", "c"}},
+		{"-name", []string{"d", "c", "b", "a", "a"}},
+		{"stars, language", []string{"c", "a", "a", "b", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			if got := repoNames(cmd.SortRepositories(repos, tt.sortBy)); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("SortRepositories(%q) = %v, want %v", tt.sortBy, got, tt.expected)
+			}
+		})
+	}
+
+	t.Run("ties are broken by owner", func(t *testing.T) {
+		sorted := cmd.SortRepositories(repos, "language,name")
+		if sorted[1].Owner.Login != "w" || sorted[2].Owner.Login != "x" {
+			t.Errorf("expected w/a before x/a, got %s then %s", sorted[1].FullName(), sorted[2].FullName())
+		}
+	})
+
+	t.Run("order is deterministic regardless of input order", func(t *testing.T) {
+		reversed := make([]cmd.Repo, len(repos))
+		for i, repo := range repos {
+			reversed[len(repos)-1-i] = repo
+		}
+		a := cmd.SortRepositories(repos, "stars")
+		b := cmd.SortRepositories(reversed, "stars")
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("expected identical order, got %v and %v", repoNames(a), repoNames(b))
+		}
+	})
+}
+
+func TestSortRepositoriesRelevance(t *testing.T) {
+	now := time.Now()
+	repos := []cmd.Repo{
+		{Name: "popular-stale", StargazerCount: 1000, UpdatedAt: now.AddDate(-3, 0, 0)},
+		{Name: "small-fresh", StargazerCount: 2, UpdatedAt: now},
+		{Name: "popular-fresh", StargazerCount: 1000, UpdatedAt: now.AddDate(0, 0, -1)},
+		{Name: "small-stale", StargazerCount: 2, UpdatedAt: now.AddDate(-3, 0, 0)},
+	}
+
+	expected := []string{"popular-fresh", "popular-stale", "small-fresh", "small-stale"}
+	if got := repoNames(cmd.SortRepositories(repos, "relevance")); !reflect.DeepEqual(got, expected) {
+		t.Errorf("SortRepositories(relevance) = %v, want %v", got, expected)
+	}
+	if cmd.RelevanceScore(repos[1]) <= cmd.RelevanceScore(repos[3]) {
+		t.Error("expected a recent update to raise the relevance score")
+	}
+}
+
+func TestParseSortKeysRejectsUnknownKeys(t *testing.T) {
+	for _, sortBy := range []string{"popularity", "stars,-bogus", "--stars"} {
+		_, err := cmd.ParseSortKeys(sortBy)
+		if err == nil {
+			t.Errorf("ParseSortKeys(%q) expected an error", sortBy)
+			continue
+		}
+		if !strings.Contains(err.Error(), "supported: created, forks") {
+			t.Errorf("expected supported keys in error, got %v", err)
+		}
+	}
+}
+
+func TestBuildRepoPreviewForkDivergence(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+
+	fork := cmd.Repo{Name: "forked-repo", Owner: cmd.Owner{Login: "user"}, IsFork: true}
+
+	t.Run("divergence shown when enabled", func(t *testing.T) {
+		cmd.SetConfig(cmd.Config{Integrations: cmd.IntegrationsConfig{Git: cmd.GitConfig{
+			Forks: cmd.ForkConfig{ShowDivergence: true},
+		}}})
+
+		preview := cmd.BuildRepoPreview(fork)
+		if !strings.Contains(preview, "Parent: upstream/forked-repo (2 ahead, 5 behind)") {
+			t.Errorf("Preview should show fork divergence, got:\n%s", preview)
+		}
+	})
+
+	t.Run("divergence hidden when disabled", func(t *testing.T) {
+		cmd.SetConfig(cmd.Config{})
+
+		preview := cmd.BuildRepoPreview(fork)
+		if strings.Contains(preview, "Parent:") {
+			t.Errorf("Preview should not show fork divergence when disabled, got:\n%s", preview)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package cmd_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func createTestResults() cmd.RepoResults {
+	return cmd.RepoResults{
+		{Repo: cmd.Repo{Name: "cloned-repo"}, Path: "/tmp/cloned-repo", Status: cmd.StatusCloned},
+		{Repo: cmd.Repo{Name: "existing-repo"}, Path: "/tmp/existing-repo", Status: cmd.StatusSkipped},
+		{Repo: cmd.Repo{Name: "broken-repo"}, Status: cmd.StatusFailed, Err: errors.New("failed to clone broken-repo: boom")},
+		{Repo: cmd.Repo{Name: "slow-repo"}, Status: cmd.StatusCancelled, Err: errors.New("clone of slow-repo cancelled")},
+	}
+}
+
+func TestRepoResultsSucceeded(t *testing.T) {
+	succeeded := createTestResults().Succeeded()
+
+	if len(succeeded) != 2 {
+		t.Fatalf("Succeeded() returned %d repos, want 2", len(succeeded))
+	}
+	if succeeded[0].Name != "cloned-repo" || succeeded[1].Name != "existing-repo" {
+		t.Errorf("Succeeded() = %v, want cloned-repo and existing-repo", succeeded)
+	}
+}
+
+func TestRepoResultsErr(t *testing.T) {
+	err := createTestResults().Err()
+	if err == nil {
+		t.Fatal("Err() returned nil, want joined errors")
+	}
+	if !strings.Contains(err.Error(), "broken-repo") || !strings.Contains(err.Error(), "slow-repo") {
+		t.Errorf("Err() should mention every failed repo, got: %v", err)
+	}
+
+	mismatched := cmd.RepoResults{{Repo: cmd.Repo{Name: "other-repo"}, Status: cmd.StatusMismatch, Err: errors.New("other-repo holds a different repository")}}
+	if err := mismatched.Err(); err == nil || !strings.Contains(err.Error(), "other-repo") {
+		t.Errorf("Err() should include mismatched repos, got: %v", err)
+	}
+
+	ok := cmd.RepoResults{{Repo: cmd.Repo{Name: "repo"}, Status: cmd.StatusCloned}}
+	if err := ok.Err(); err != nil {
+		t.Errorf("Err() for successful results = %v, want nil", err)
+	}
+}
+
+func TestRepoResultsExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  cmd.RepoResults
+		expected int
+	}{
+		{
+			name:     "no results",
+			results:  nil,
+			expected: 0,
+		},
+		{
+			name: "all succeeded",
+			results: cmd.RepoResults{
+				{Status: cmd.StatusCloned},
+				{Status: cmd.StatusSkipped},
+			},
+			expected: 0,
+		},
+		{
+			name:     "partial failure",
+			results:  createTestResults(),
+			expected: cmd.ExitCodePartialFailure,
+		},
+		{
+			name: "mismatched directory",
+			results: cmd.RepoResults{
+				{Status: cmd.StatusCloned},
+				{Status: cmd.StatusMismatch, Err: errors.New("holds a different repository")},
+			},
+			expected: cmd.ExitCodePartialFailure,
+		},
+		{
+			name: "total failure",
+			results: cmd.RepoResults{
+				{Status: cmd.StatusFailed},
+				{Status: cmd.StatusCancelled},
+			},
+			expected: cmd.ExitCodeTotalFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := tt.results.ExitCode(); code != tt.expected {
+				t.Errorf("ExitCode() = %d, want %d", code, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExitCodeFor(t *testing.T) {
+	if code := cmd.ExitCodeFor(errors.New("plain")); code != cmd.ExitCodeError {
+		t.Errorf("ExitCodeFor(plain error) = %d, want %d", code, cmd.ExitCodeError)
+	}
+
+	wrapped := fmt.Errorf("wrapped: %w", &cmd.ExitError{Code: cmd.ExitCodePartialFailure, Err: errors.New("partial")})
+	if code := cmd.ExitCodeFor(wrapped); code != cmd.ExitCodePartialFailure {
+		t.Errorf("ExitCodeFor(wrapped ExitError) = %d, want %d", code, cmd.ExitCodePartialFailure)
+	}
+}
+
+func TestPrintResultSummary(t *testing.T) {
+	var buf bytes.Buffer
+	cmd.PrintResultSummary(&buf, createTestResults())
+	output := buf.String()
+
+	for _, expected := range []string{
+		"cloned-repo", "/tmp/cloned-repo",
+		"existing-repo", "skipped",
+		"broken-repo", "boom",
+		"slow-repo", "cancelled",
+		"4 repositories: 1 cloned, 1 skipped, 1 failed, 1 cancelled",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("PrintResultSummary() output missing %q:\n%s", expected, output)
+		}
+	}
+}
+
+func TestPrintResultSummaryEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	cmd.PrintResultSummary(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("PrintResultSummary() with no results should print nothing, got %q", buf.String())
+	}
+}
+
+func TestPrintInterruptedSummary(t *testing.T) {
+	var buf bytes.Buffer
+	cmd.PrintInterruptedSummary(&buf, cmd.RepoResults{
+		{Repo: cmd.Repo{Name: "done-repo"}, Status: cmd.StatusCloned},
+		{Repo: cmd.Repo{Name: "aborted-repo"}, Status: cmd.StatusCancelled},
+	})
+
+	output := buf.String()
+	for _, expected := range []string{"1 finished, 1 aborted", "Finished: done-repo", "Aborted:  aborted-repo"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("PrintInterruptedSummary() output missing %q:\n%s", expected, output)
+		}
+	}
+}
@@ -250,3 +250,14 @@ func TestBuildReloadCommand(t *testing.T) {
 		t.Errorf("expected reload command to contain sort flag, got: %s", reloadCmd)
 	}
 }
+
+func TestBuildReloadCommandQuotesFilter(t *testing.T) {
+	cmd.Filter = "lang:go (topic:cli OR stars:>10) -is:archived owner:o'brien"
+	defer func() { cmd.Filter = "" }()
+
+	reloadCmd := cmd.BuildReloadCommand("")
+	expected := `--filter 'lang:go (topic:cli OR stars:>10) -is:archived owner:o'\''brien'`
+	if !strings.Contains(reloadCmd, expected) {
+		t.Errorf("expected reload command to contain %s, got: %s", expected, reloadCmd)
+	}
+}
@@ -0,0 +1,59 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		wantErr  bool
+	}{
+		{"1024", 1024, false},
+		{"10KB", 10 * 1024, false},
+		{"1k", 1024, false},
+		{"500MB", 500 * 1024 * 1024, false},
+		{"1.5G", 1536 * 1024 * 1024, false},
+		{" 2gb ", 2 * 1024 * 1024 * 1024, false},
+		{"", 0, true},
+		{"MB", 0, true},
+		{"-5MB", 0, true},
+		{"lots", 0, true},
+		{"1MK", 0, true},
+		{"5GGG", 0, true},
+		{"2KBB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := cmd.ParseSize(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && result != tt.expected {
+				t.Errorf("ParseSize(%q) = %d, want %d", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		bytes    int64
+		expected string
+	}{
+		{512, "512 B"},
+		{2048, "2.0 KB"},
+		{1536 * 1024, "1.5 MB"},
+		{3 * 1024 * 1024 * 1024, "3.0 GB"},
+	}
+
+	for _, tt := range tests {
+		if got := cmd.FormatSize(tt.bytes); got != tt.expected {
+			t.Errorf("FormatSize(%d) = %q, want %q", tt.bytes, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func TestFindLocalClones(t *testing.T) {
+	root := t.TempDir()
+	createLocalClone(t, filepath.Join(root, "user", "first-repo"))
+	createLocalClone(t, filepath.Join(root, "second-repo"))
+	createLocalClone(t, filepath.Join(root, "second-repo", "vendor", "nested-repo"))
+	createLocalClone(t, filepath.Join(root, ".cache", "hidden-repo"))
+	if err := os.MkdirAll(filepath.Join(root, "plain-dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	clones, err := cmd.FindLocalClones(root)
+	if err != nil {
+		t.Fatalf("FindLocalClones() error = %v", err)
+	}
+
+	expected := []string{filepath.Join(root, "second-repo"), filepath.Join(root, "user", "first-repo")}
+	if len(clones) != len(expected) {
+		t.Fatalf("FindLocalClones() = %v, want %v", clones, expected)
+	}
+	for i := range expected {
+		if clones[i] != expected[i] {
+			t.Errorf("FindLocalClones()[%d] = %s, want %s", i, clones[i], expected[i])
+		}
+	}
+
+	if clones, err := cmd.FindLocalClones(filepath.Join(root, "missing")); err != nil || clones != nil {
+		t.Errorf("FindLocalClones() on missing dir = %v, %v, want nil, nil", clones, err)
+	}
+}
+
+func TestGetLocalRepoStatus(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+
+	tests := []struct {
+		name     string
+		expected cmd.LocalRepoStatus
+	}{
+		{"clean-repo", cmd.LocalRepoStatus{Branch: "main", Upstream: "origin/main"}},
+		{"dirty-repo", cmd.LocalRepoStatus{Branch: "main", Upstream: "origin/main", Dirty: 1}},
+		{"stashed-repo", cmd.LocalRepoStatus{Branch: "main", Upstream: "origin/main", Stashes: 2}},
+		{"ahead-repo", cmd.LocalRepoStatus{Branch: "main", Upstream: "origin/main", Ahead: 3, Behind: 1}},
+		{"local-only-repo", cmd.LocalRepoStatus{Branch: "main"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(ts.env.tmpDir, tt.name)
+			createLocalClone(t, path)
+
+			status := cmd.GetLocalRepoStatus(context.Background(), path)
+			if status.Error != "" {
+				t.Fatalf("GetLocalRepoStatus() error = %s", status.Error)
+			}
+			if status.Branch != tt.expected.Branch || status.Upstream != tt.expected.Upstream ||
+				status.Dirty != tt.expected.Dirty || status.Stashes != tt.expected.Stashes ||
+				status.Ahead != tt.expected.Ahead || status.Behind != tt.expected.Behind {
+				t.Errorf("GetLocalRepoStatus() = %+v, want %+v", status, tt.expected)
+			}
+			if status.LastCommit != "abc1234 Initial commit (2 days ago)" {
+				t.Errorf("LastCommit = %q", status.LastCommit)
+			}
+		})
+	}
+}
+
+func TestFilterLocalStatuses(t *testing.T) {
+	statuses := []cmd.LocalRepoStatus{
+		{Name: "clean", Branch: "main", Upstream: "origin/main"},
+		{Name: "dirty", Branch: "main", Upstream: "origin/main", Dirty: 2},
+		{Name: "stashed", Branch: "main", Upstream: "origin/main", Stashes: 1},
+		{Name: "ahead", Branch: "main", Upstream: "origin/main", Ahead: 1},
+		{Name: "local-only", Branch: "feature"},
+	}
+
+	tests := []struct {
+		name     string
+		dirty    bool
+		unpushed bool
+		expected []string
+	}{
+		{"no filters", false, false, []string{"clean", "dirty", "stashed", "ahead", "local-only"}},
+		{"dirty", true, false, []string{"dirty", "stashed"}},
+		{"unpushed", false, true, []string{"ahead", "local-only"}},
+		{"dirty or unpushed", true, true, []string{"dirty", "stashed", "ahead", "local-only"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := cmd.FilterLocalStatuses(statuses, tt.dirty, tt.unpushed)
+			var names []string
+			for _, status := range filtered {
+				names = append(names, status.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("FilterLocalStatuses() = %v, want %v", names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterLocalStatusesNoMatches(t *testing.T) {
+	filtered := cmd.FilterLocalStatuses([]cmd.LocalRepoStatus{{Name: "clean", Branch: "main"}}, true, false)
+	data, err := json.Marshal(filtered)
+	if err != nil {
+		t.Fatalf("json.Marshal() returned error: %v", err)
+	}
+	if string(data) != "[]" {
+		t.Errorf("FilterLocalStatuses() with no matches marshals to %s, want []", data)
+	}
+}
+
+func TestPrintStatusTable(t *testing.T) {
+	var buf bytes.Buffer
+	cmd.PrintStatusTable(&buf, []cmd.LocalRepoStatus{
+		{Name: "user/ahead-repo", Branch: "main", Upstream: "origin/main", Ahead: 3, Behind: 1, LastCommit: "abc1234 Fix"},
+		{Name: "user/local-only", Branch: "feature"},
+		{Name: "user/broken", Error: "git rev-parse failed: not a git repository"},
+	})
+
+	output := buf.String()
+	for _, expected := range []string{"REPO", "user/ahead-repo", "3/1", "abc1234 Fix", "no upstream", "not a git repository"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("PrintStatusTable() output missing %q:\n%s", expected, output)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package cmd_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func createLocalClone(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(path, ".git"), 0o755); err != nil {
+		t.Fatalf("Failed to create clone %s: %v", path, err)
+	}
+}
+
+func createSyncRepos(names ...string) []cmd.Repo {
+	var repos []cmd.Repo
+	for _, name := range names {
+		repos = append(repos, cmd.Repo{Name: name, HTMLURL: "https://github.com/user/" + name, Owner: cmd.Owner{Login: "user"}})
+	}
+	return repos
+}
+
+func TestFindClonedRepos(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+
+	createLocalClone(t, filepath.Join(ts.env.tmpDir, "Projects", "user", "cloned-repo"))
+	if err := os.MkdirAll(filepath.Join(ts.env.tmpDir, "Projects", "user", "plain-dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	cloned := cmd.FindClonedRepos(createSyncRepos("cloned-repo", "plain-dir", "missing-repo"))
+	if len(cloned) != 1 || cloned[0].Name != "cloned-repo" {
+		t.Errorf("FindClonedRepos() = %v, want only cloned-repo", cloned)
+	}
+}
+
+func TestSyncRepos(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	recorder := recordExecCommands(t)
+	cmd.SetConfig(cmd.Config{Repos: cmd.ReposConfig{ProjectsDir: "~/Projects", PerUserDir: true}})
+
+	repos := createSyncRepos("current-repo", "behind-repo", "dirty-repo", "unrelated-repo")
+	for _, repo := range repos {
+		createLocalClone(t, filepath.Join(env.tmpDir, "Projects", "user", repo.Name))
+	}
+
+	results := cmd.SyncRepos(context.Background(), repos)
+
+	expected := map[string]cmd.ResultStatus{
+		"current-repo":   cmd.StatusUpToDate,
+		"behind-repo":    cmd.StatusUpdated,
+		"dirty-repo":     cmd.StatusDirty,
+		"unrelated-repo": cmd.StatusMismatch,
+	}
+	for _, result := range results {
+		if result.Status != expected[result.Repo.Name] {
+			t.Errorf("%s status = %s, want %s (err: %v)", result.Repo.Name, result.Status, expected[result.Repo.Name], result.Err)
+		}
+	}
+
+	dirtyPath := filepath.Join(env.tmpDir, "Projects", "user", "dirty-repo")
+	if recorder.has("git", "-C", dirtyPath, "fetch", "--prune", "origin") {
+		t.Error("dirty working trees should not be fetched")
+	}
+	if code := results.ExitCode(); code != cmd.ExitCodePartialFailure {
+		t.Errorf("ExitCode() = %d, want %d for the mismatched clone", code, cmd.ExitCodePartialFailure)
+	}
+}
+
+func TestSyncReposEmpty(t *testing.T) {
+	if results := cmd.SyncRepos(context.Background(), nil); results != nil {
+		t.Errorf("SyncRepos() with no repos = %v, want nil", results)
+	}
+}
@@ -0,0 +1,136 @@
+package cmd_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func setTmuxConfig(t *testing.T, tmux cmd.TmuxConfig) string {
+	t.Helper()
+	env := setupTempHome(t)
+	t.Cleanup(env.cleanup)
+	cmd.SetConfig(cmd.Config{
+		Repos:        cmd.ReposConfig{ProjectsDir: "~/Projects"},
+		Integrations: cmd.IntegrationsConfig{Tmux: tmux},
+	})
+	return filepath.Join(env.tmpDir, "Projects")
+}
+
+func TestTmuxName(t *testing.T) {
+	repo := cmd.Repo{Name: "site.github.io", Owner: cmd.Owner{Login: "user"}}
+	if got := cmd.TmuxName(repo); got != "user/site_github_io" {
+		t.Errorf("TmuxName() = %q, want %q", got, "user/site_github_io")
+	}
+}
+
+func TestOpenInTmuxCreatesSessions(t *testing.T) {
+	projects := setTmuxConfig(t, cmd.TmuxConfig{
+		Mode:   cmd.TmuxModeSession,
+		Switch: true,
+		Windows: []cmd.TmuxWindow{
+			{Name: "code", Layout: "main-vertical", Panes: []string{"nvim .", "", "echo {name}"}},
+			{Name: "git", Panes: []string{"lazygit"}},
+		},
+	})
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	recorder := recordExecCommands(t)
+
+	repo := cmd.Repo{Name: "go-repo", Owner: cmd.Owner{Login: "user"}}
+	if err := cmd.OpenInTmux([]cmd.Repo{repo}); err != nil {
+		t.Fatalf("OpenInTmux() error = %v", err)
+	}
+
+	repoPath := filepath.Join(projects, "go-repo")
+	for _, expected := range [][]string{
+		{"tmux", "new-session", "-d", "-s", "user/go-repo", "-n", "code", "-P", "-F", "#{window_id} #{pane_id}", "-c", repoPath},
+		{"tmux", "send-keys", "-t", "%1", "nvim .", "Enter"},
+		{"tmux", "split-window", "-d", "-t", "@1", "-c", repoPath},
+		{"tmux", "send-keys", "-t", "%2", "echo go-repo", "Enter"},
+		{"tmux", "select-layout", "-t", "@1", "main-vertical"},
+		{"tmux", "new-window", "-d", "-t", "=user/go-repo:", "-n", "git"},
+		{"tmux", "send-keys", "-t", "%1", "lazygit", "Enter"},
+		{"tmux", "switch-client", "-t", "=user/go-repo"},
+	} {
+		if !recorder.has(expected...) {
+			t.Errorf("expected command %v, got %v", expected, recorder.commands)
+		}
+	}
+}
+
+func TestOpenInTmuxReusesExistingSession(t *testing.T) {
+	setTmuxConfig(t, cmd.TmuxConfig{Switch: true})
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	recorder := recordExecCommands(t)
+
+	repo := cmd.Repo{Name: "existing-repo", Owner: cmd.Owner{Login: "user"}}
+	if err := cmd.OpenInTmux([]cmd.Repo{repo}); err != nil {
+		t.Fatalf("OpenInTmux() error = %v", err)
+	}
+
+	if recorder.has("tmux", "new-session") {
+		t.Error("expected the existing session to be reused")
+	}
+	if !recorder.has("tmux", "switch-client", "-t", "=user/existing-repo") {
+		t.Errorf("expected switch to existing session, got %v", recorder.commands)
+	}
+}
+
+func TestOpenInTmuxWindowMode(t *testing.T) {
+	setTmuxConfig(t, cmd.TmuxConfig{Mode: cmd.TmuxModeWindow, Session: "existing", Switch: true})
+	t.Setenv("TMUX", "")
+	recorder := recordExecCommands(t)
+
+	repos := []cmd.Repo{
+		{Name: "existing-window", Owner: cmd.Owner{Login: "user"}},
+		{Name: "new-window", Owner: cmd.Owner{Login: "user"}},
+	}
+	if err := cmd.OpenInTmux(repos); err != nil {
+		t.Fatalf("OpenInTmux() error = %v", err)
+	}
+
+	if recorder.has("tmux", "new-session") {
+		t.Error("expected windows to be added to the existing shared session")
+	}
+	if recorder.has("tmux", "new-window", "-d", "-t", "=existing:", "-n", "user/existing-window") {
+		t.Error("expected the existing window to be reused")
+	}
+	for _, expected := range [][]string{
+		{"tmux", "new-window", "-d", "-t", "=existing:", "-n", "user/new-window"},
+		{"tmux", "select-window", "-t", "@9"},
+		{"tmux", "attach-session", "-t", "=existing"},
+	} {
+		if !recorder.has(expected...) {
+			t.Errorf("expected command %v, got %v", expected, recorder.commands)
+		}
+	}
+}
+
+func TestOpenInTmuxWithoutSwitch(t *testing.T) {
+	setTmuxConfig(t, cmd.TmuxConfig{})
+	recorder := recordExecCommands(t)
+
+	if err := cmd.OpenInTmux([]cmd.Repo{{Name: "go-repo", Owner: cmd.Owner{Login: "user"}}}); err != nil {
+		t.Fatalf("OpenInTmux() error = %v", err)
+	}
+	if !recorder.has("tmux", "new-session", "-d", "-s", "user/go-repo") {
+		t.Errorf("expected session to be created, got %v", recorder.commands)
+	}
+	if recorder.has("tmux", "switch-client") || recorder.has("tmux", "attach-session") {
+		t.Error("expected no client switch when switch is disabled")
+	}
+}
+
+func TestOpenInTmuxDryRun(t *testing.T) {
+	setTmuxConfig(t, cmd.TmuxConfig{Switch: true})
+	enableDryRun(t)
+	recorder := recordExecCommands(t)
+
+	if err := cmd.OpenInTmux([]cmd.Repo{{Name: "go-repo", Owner: cmd.Owner{Login: "user"}}}); err != nil {
+		t.Fatalf("OpenInTmux() error = %v", err)
+	}
+	if len(recorder.commands) != 0 {
+		t.Errorf("expected no tmux commands in dry run, got %v", recorder.commands)
+	}
+}
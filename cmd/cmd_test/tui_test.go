@@ -0,0 +1,129 @@
+package cmd_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+func createPickerLines() []string {
+	return []string{
+		"user/gh-repo-man\tgh-repo-man  Go",
+		"user/dots\tdots  Shell",
+		"acme/tool\ttool  Rust",
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		matches bool
+	}{
+		{"", "anything", true},
+		{"grm", "gh-repo-man", true},
+		{"GRM", "gh-repo-man", true},
+		{"mrg", "gh-repo-man", false},
+		{"dots", "stod", false},
+	}
+	for _, tt := range tests {
+		if _, ok := cmd.FuzzyMatch(tt.pattern, tt.text); ok != tt.matches {
+			t.Errorf("FuzzyMatch(%q, %q) matched = %v, want %v", tt.pattern, tt.text, ok, tt.matches)
+		}
+	}
+
+	consecutive, _ := cmd.FuzzyMatch("repo", "gh-repo-man")
+	scattered, _ := cmd.FuzzyMatch("repo", "r-e-p-o")
+	if consecutive <= scattered {
+		t.Errorf("expected consecutive matches to score higher, got %d <= %d", consecutive, scattered)
+	}
+}
+
+func TestBuiltinPickerRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"enter picks the first line", "\r", []string{"user/gh-repo-man"}},
+		{"query filters lines", "tool\r", []string{"acme/tool"}},
+		{"words must all match", "to ru\r", []string{"acme/tool"}},
+		{"backspace edits the query", "dotx\x7f\r", []string{"user/dots"}},
+		{"arrows move the cursor", "\x1b[B\x1b[B\x1b[A\r", []string{"user/dots"}},
+		{"tab selects several lines", "\t\x1b[B\t\r", []string{"user/gh-repo-man", "acme/tool"}},
+		{"tab twice deselects", "\t\x1b[A\t\r", []string{"user/dots"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			picker := &cmd.BuiltinPicker{Lines: createPickerLines(), Width: 40, Height: 10}
+			got, err := picker.Run(strings.NewReader(tt.input), &bytes.Buffer{})
+			if err != nil {
+				t.Fatalf("Run() returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Run() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuiltinPickerCancel(t *testing.T) {
+	for _, input := range []string{"\x03", "\x1b", "", "nomatch\r"} {
+		picker := &cmd.BuiltinPicker{Lines: createPickerLines(), Width: 40, Height: 10}
+		if _, err := picker.Run(strings.NewReader(input), &bytes.Buffer{}); err == nil || err.Error() != "selection cancelled" {
+			t.Errorf("Run(%q) error = %v, want selection cancelled", input, err)
+		}
+	}
+}
+
+func TestBuiltinPickerPreviewAndReload(t *testing.T) {
+	reloads := 0
+	picker := &cmd.BuiltinPicker{
+		Lines:  createPickerLines()[:1],
+		Width:  100,
+		Height: 10,
+		Preview: func(key string) string {
+			return "# preview of " + key
+		},
+		Reload: func() ([]string, error) {
+			reloads++
+			return createPickerLines(), nil
+		},
+	}
+
+	var out bytes.Buffer
+	got, err := picker.Run(strings.NewReader("tool\x12\r"), &out)
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+	if reloads != 1 || !reflect.DeepEqual(got, []string{"acme/tool"}) {
+		t.Errorf("expected reloaded lines to be picked, got %v after %d reloads", got, reloads)
+	}
+	for _, expected := range []string{"# preview of user/gh-repo-man", "# preview of acme/tool", "1/3"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected output to contain %q", expected)
+		}
+	}
+}
+
+func TestBuiltinPickerExpectedKeys(t *testing.T) {
+	for input, expected := range map[string]string{"\x0f": "ctrl-o", "\x1bs": "alt-s", "\r": ""} {
+		picker := &cmd.BuiltinPicker{Lines: createPickerLines(), Width: 40, Height: 10, Expect: []string{"alt-s", "ctrl-o"}}
+		got, err := picker.Run(strings.NewReader(input), &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("Run(%q) returned error: %v", input, err)
+		}
+		if picker.Key != expected || !reflect.DeepEqual(got, []string{"user/gh-repo-man"}) {
+			t.Errorf("Run(%q) = %v with key %q, want key %q", input, got, picker.Key, expected)
+		}
+	}
+
+	picker := &cmd.BuiltinPicker{Lines: createPickerLines(), Width: 40, Height: 10, Expect: []string{"ctrl-m"}}
+	got, err := picker.Run(strings.NewReader("\r"), &bytes.Buffer{})
+	if err != nil || picker.Key != "" || !reflect.DeepEqual(got, []string{"user/gh-repo-man"}) {
+		t.Errorf("Run(enter) with ctrl-m expected = %v with key %q, %v, want a plain selection", got, picker.Key, err)
+	}
+}
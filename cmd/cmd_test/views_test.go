@@ -0,0 +1,186 @@
+package cmd_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/2KAbhishek/gh-repo-man/cmd"
+)
+
+const (
+	mockStarredRepoJSON = `{"name":"tool","description":"a starred tool","html_url":"https://github.com/acme/tool","stargazers_count":42,"forks_count":7,"watchers_count":42,"open_issues_count":3,"owner":{"login":"acme"},"created_at":"2021-05-01T00:00:00Z","updated_at":"2024-02-01T00:00:00Z","size":512,"homepage":"","fork":false,"archived":true,"private":false,"is_template":false,"visibility":"public","topics":["cli"],"language":"Rust"}`
+	mockRepo1RESTJSON   = `{"name":"repo1","html_url":"https://github.com/user/repo1","owner":{"login":"user"},"language":"Go"}`
+)
+
+func createViews() map[string]cmd.ViewConfig {
+	return map[string]cmd.ViewConfig{
+		"backend": {Users: []string{"acme", "widgets"}, Type: "source", Language: "go", Sort: "pushed"},
+		"stars":   {Source: cmd.ViewSourceStarred, Users: []string{"acme"}},
+		"tools":   {Source: cmd.ViewSourceSearch, Query: "topic:cli", Users: []string{"acme"}},
+	}
+}
+
+func resetView(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		cmd.View = ""
+		_ = cmd.ApplyView("", func(string) bool { return false })
+	})
+}
+
+func TestNextView(t *testing.T) {
+	cmd.SetConfig(cmd.Config{Views: createViews()})
+
+	tests := []struct {
+		current  string
+		expected string
+	}{
+		{"", "backend"},
+		{"backend", "stars"},
+		{"tools", "backend"},
+		{"removed", "backend"},
+	}
+	for _, tt := range tests {
+		if got := cmd.NextView(tt.current); got != tt.expected {
+			t.Errorf("NextView(%q) = %q, want %q", tt.current, got, tt.expected)
+		}
+	}
+
+	cmd.SetConfig(cmd.Config{})
+	if got := cmd.NextView("backend"); got != "" {
+		t.Errorf("expected no next view without views, got %q", got)
+	}
+}
+
+func TestApplyViewRejectsUnknownView(t *testing.T) {
+	resetView(t)
+	cmd.SetConfig(cmd.Config{Views: createViews()})
+
+	err := cmd.ApplyView("frontend", func(string) bool { return false })
+	if err == nil || !strings.Contains(err.Error(), "backend, stars, tools") {
+		t.Errorf("expected unknown view error listing the views, got %v", err)
+	}
+}
+
+func TestCycleView(t *testing.T) {
+	env := setupTempHome(t)
+	defer env.cleanup()
+	cmd.SetConfig(cmd.Config{Views: createViews()})
+
+	if err := cmd.SaveCurrentView("stars"); err != nil {
+		t.Fatalf("SaveCurrentView() returned error: %v", err)
+	}
+	next, err := cmd.CycleView()
+	if err != nil || next != "tools" {
+		t.Fatalf("CycleView() = %q, %v, want tools", next, err)
+	}
+	if current, err := cmd.LoadCurrentView(); err != nil || current != "tools" {
+		t.Errorf("LoadCurrentView() = %q, %v, want tools", current, err)
+	}
+	if header := cmd.ViewHeader(next); !strings.HasPrefix(header, "View: tools") {
+		t.Errorf("unexpected header %q", header)
+	}
+}
+
+func TestGetViewReposStarred(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+
+	repos, err := cmd.GetViewRepos(createViews()["stars"], "")
+	if err != nil {
+		t.Fatalf("GetViewRepos() returned error: %v", err)
+	}
+	if got := repoNames(repos); !reflect.DeepEqual(got, []string{"tool", "repo1"}) {
+		t.Fatalf("expected repositories from every page, got %v", got)
+	}
+
+	expected := cmd.Repo{
+		Name: "tool", Description: "a starred tool", HTMLURL: "https://github.com/acme/tool",
+		StargazerCount: 42, ForkCount: 7, Watchers: cmd.Count{TotalCount: 42}, Issues: cmd.Count{TotalCount: 3},
+		Owner: cmd.Owner{Login: "acme"}, CreatedAt: time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), DiskUsage: 512, IsArchived: true,
+		Visibility: "PUBLIC", Topics: []cmd.Topic{{Name: "cli"}}, PrimaryLanguage: cmd.Language{Name: "Rust"},
+	}
+	if !reflect.DeepEqual(repos[0], expected) {
+		t.Errorf("GetViewRepos() converted %+v, want %+v", repos[0], expected)
+	}
+}
+
+func TestGetViewReposSearch(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+
+	repos, err := cmd.GetViewRepos(createViews()["tools"], "")
+	if err != nil {
+		t.Fatalf("GetViewRepos() returned error: %v", err)
+	}
+	if got := repoNames(repos); !reflect.DeepEqual(got, []string{"tool"}) {
+		t.Errorf("expected search results, got %v", got)
+	}
+}
+
+func TestGetViewReposMergesOwners(t *testing.T) {
+	ts := setupMockTest(t)
+	defer ts.cleanup()
+	recorder := recordExecCommands(t)
+
+	repos, err := cmd.GetViewRepos(createViews()["backend"], "")
+	if err != nil {
+		t.Fatalf("GetViewRepos() returned error: %v", err)
+	}
+	for _, user := range []string{"acme", "widgets"} {
+		if !recorder.has(cmd.JSONFields, user) {
+			t.Errorf("expected repositories fetched for %s, got %v", user, recorder.commands)
+		}
+	}
+	if got := repoNames(repos); !reflect.DeepEqual(got, []string{"userRepo1"}) {
+		t.Errorf("expected duplicate repositories merged, got %v", got)
+	}
+
+	if _, err := cmd.GetViewRepos(createViews()["backend"], "other"); err != nil {
+		t.Fatalf("GetViewRepos() with a user returned error: %v", err)
+	}
+	if !recorder.has(cmd.JSONFields, "other") {
+		t.Errorf("expected --user to replace the view's users, got %v", recorder.commands)
+	}
+}
+
+func TestBuildReloadCommandWithViews(t *testing.T) {
+	resetView(t)
+	cmd.SetConfig(cmd.Config{Views: createViews()})
+	cmd.SortBy = "stars"
+	cmd.LanguageFilter = "Go"
+	defer func() {
+		cmd.SortBy = ""
+		cmd.LanguageFilter = ""
+	}()
+
+	if err := cmd.ApplyView("backend", func(flag string) bool { return flag == "sort" }); err != nil {
+		t.Fatalf("ApplyView() returned error: %v", err)
+	}
+
+	reloadCmd := cmd.BuildReloadCommand("")
+	if !strings.Contains(reloadCmd, "--current-view") || !strings.Contains(reloadCmd, "--sort stars") {
+		t.Errorf("expected reload command to follow the current view and keep explicit flags, got: %s", reloadCmd)
+	}
+	if strings.Contains(reloadCmd, "--language") {
+		t.Errorf("expected reload command to leave unset flags to the view, got: %s", reloadCmd)
+	}
+}
+
+func TestPrintViews(t *testing.T) {
+	cmd.SetConfig(cmd.Config{Views: createViews()})
+
+	var buf bytes.Buffer
+	cmd.PrintViews(&buf)
+	expected := `backend: owned; users acme, widgets; type "source"; language "go"; sort "pushed"
+stars: starred; users acme
+tools: search; query "topic:cli"; users acme
+`
+	if buf.String() != expected {
+		t.Errorf("PrintViews() =\n%s\nwant\n%s", buf.String(), expected)
+	}
+}
@@ -17,14 +17,28 @@ type IconConfig struct {
 type ReposConfig struct {
 	ProjectsDir string `yaml:"projects_dir"`
 	PerUserDir  bool   `yaml:"per_user_dir"`
+	Layout      string `yaml:"layout"`
 	SortBy      string `yaml:"sort_by"`
 	RepoType    string `yaml:"repo_type"`
 	Language    string `yaml:"language"`
+	Filter      string `yaml:"filter"`
 }
 
 type UIConfig struct {
-	ShowReadmeInPreview bool       `yaml:"show_readme_in_preview"`
-	Icons               IconConfig `yaml:"icons"`
+	ShowReadmeInPreview bool              `yaml:"show_readme_in_preview"`
+	Icons               IconConfig        `yaml:"icons"`
+	Columns             []string          `yaml:"columns"`
+	Picker              string            `yaml:"picker"`
+	Actions             map[string]string `yaml:"actions"`
+	Fzf                 FzfConfig         `yaml:"fzf"`
+}
+
+type FzfConfig struct {
+	ExtraArgs     []string          `yaml:"extra_args"`
+	PreviewWindow string            `yaml:"preview_window"`
+	Colors        string            `yaml:"colors"`
+	Binds         map[string]string `yaml:"binds"`
+	Multi         *bool             `yaml:"multi"`
 }
 
 type CacheConfig struct {
@@ -33,10 +47,17 @@ type CacheConfig struct {
 	Username string `yaml:"username"`
 }
 
+type DiskSpaceConfig struct {
+	Mode   string `yaml:"mode"`
+	Margin string `yaml:"margin"`
+}
+
 type PerformanceConfig struct {
-	RepoLimit           string      `yaml:"repo_limit"`
-	MaxConcurrentClones int         `yaml:"max_concurrent_clones"`
-	Cache               CacheConfig `yaml:"cache"`
+	RepoLimit           string          `yaml:"repo_limit"`
+	MaxConcurrentClones int             `yaml:"max_concurrent_clones"`
+	CloneRetries        int             `yaml:"clone_retries"`
+	DiskSpace           DiskSpaceConfig `yaml:"disk_space"`
+	Cache               CacheConfig     `yaml:"cache"`
 }
 
 type CommandConfig struct {
@@ -45,25 +66,143 @@ type CommandConfig struct {
 	Args    []string `yaml:"args"`
 }
 
+type ForkConfig struct {
+	AddUpstream    bool   `yaml:"add_upstream"`
+	RemoteName     string `yaml:"remote_name"`
+	TrackUpstream  bool   `yaml:"track_upstream"`
+	ShowDivergence bool   `yaml:"show_divergence"`
+}
+
+type CloneProfile struct {
+	Depth       *int     `yaml:"depth"`
+	Filter      string   `yaml:"filter"`
+	SparsePaths []string `yaml:"sparse_paths"`
+	Args        []string `yaml:"args"`
+}
+
+type ProfilePattern struct {
+	Pattern string `yaml:"pattern"`
+	Profile string `yaml:"profile"`
+}
+
+type RuleMatch struct {
+	Owner    string `yaml:"owner"`
+	Name     string `yaml:"name"`
+	Language string `yaml:"language"`
+	Topic    string `yaml:"topic"`
+	MinSize  string `yaml:"min_size"`
+	MaxSize  string `yaml:"max_size"`
+}
+
+type CloneRule struct {
+	Match       RuleMatch      `yaml:"match"`
+	CloneDepth  *int           `yaml:"clone_depth"`
+	CloneArgs   []string       `yaml:"clone_args"`
+	Profile     string         `yaml:"profile"`
+	ProjectsDir string         `yaml:"projects_dir"`
+	Protocol    string         `yaml:"protocol"`
+	PostClone   *CommandConfig `yaml:"post_clone"`
+}
+
 type GitConfig struct {
-	CloneDepth int      `yaml:"clone_depth"`
-	CloneArgs  []string `yaml:"clone_args"`
+	Protocol        string                  `yaml:"protocol"`
+	CloneDepth      int                     `yaml:"clone_depth"`
+	CloneArgs       []string                `yaml:"clone_args"`
+	Filter          string                  `yaml:"filter"`
+	SparsePaths     []string                `yaml:"sparse_paths"`
+	Profiles        map[string]CloneProfile `yaml:"profiles"`
+	ProfilePatterns []ProfilePattern        `yaml:"profile_patterns"`
+	Rules           []CloneRule             `yaml:"rules"`
+	OnExisting      string                  `yaml:"on_existing"`
+	Forks           ForkConfig              `yaml:"forks"`
+}
+
+type HookConfig struct {
+	Name      string    `yaml:"name"`
+	Command   string    `yaml:"command"`
+	Args      []string  `yaml:"args"`
+	Dir       string    `yaml:"dir"`
+	When      RuleMatch `yaml:"when"`
+	Once      bool      `yaml:"once"`
+	OnFailure string    `yaml:"on_failure"`
+}
+
+type BootstrapConfig struct {
+	Enabled  bool                `yaml:"enabled"`
+	Confirm  bool                `yaml:"confirm"`
+	Allow    []string            `yaml:"allow"`
+	Commands map[string][]string `yaml:"commands"`
+}
+
+type TmuxWindow struct {
+	Name   string   `yaml:"name"`
+	Layout string   `yaml:"layout"`
+	Panes  []string `yaml:"panes"`
+}
+
+type TmuxConfig struct {
+	Enabled bool         `yaml:"enabled"`
+	Mode    string       `yaml:"mode"`
+	Session string       `yaml:"session"`
+	Switch  bool         `yaml:"switch"`
+	Windows []TmuxWindow `yaml:"windows"`
 }
 
 type IntegrationsConfig struct {
-	Git       GitConfig     `yaml:"git"`
-	PostClone CommandConfig `yaml:"post_clone"`
+	Git       GitConfig       `yaml:"git"`
+	Bootstrap BootstrapConfig `yaml:"bootstrap"`
+	Hooks     []HookConfig    `yaml:"hooks"`
+	Tmux      TmuxConfig      `yaml:"tmux"`
+	PostClone CommandConfig   `yaml:"post_clone"`
+}
+
+type IdentityConfig struct {
+	Owners     []string `yaml:"owners"`
+	Name       string   `yaml:"name"`
+	Email      string   `yaml:"email"`
+	SigningKey string   `yaml:"signing_key"`
+	SSHKey     string   `yaml:"ssh_key"`
+}
+
+type ViewConfig struct {
+	Source   string   `yaml:"source"`
+	Users    []string `yaml:"users"`
+	Query    string   `yaml:"query"`
+	Type     string   `yaml:"type"`
+	Language string   `yaml:"language"`
+	Filter   string   `yaml:"filter"`
+	Sort     string   `yaml:"sort"`
 }
 
 type Config struct {
-	Repos        ReposConfig        `yaml:"repos"`
-	UI           UIConfig           `yaml:"ui"`
-	Performance  PerformanceConfig  `yaml:"performance"`
-	Integrations IntegrationsConfig `yaml:"integrations"`
+	Repos        ReposConfig           `yaml:"repos"`
+	UI           UIConfig              `yaml:"ui"`
+	Performance  PerformanceConfig     `yaml:"performance"`
+	Integrations IntegrationsConfig    `yaml:"integrations"`
+	Identities   []IdentityConfig      `yaml:"identities"`
+	Views        map[string]ViewConfig `yaml:"views"`
 }
 
 const DefaultConfigPath = "~/.config/gh-repo-man/config.yml"
 
+const (
+	ProtocolSSH   = "ssh"
+	ProtocolHTTPS = "https"
+)
+
+const (
+	OnExistingSkip  = "skip"
+	OnExistingPull  = "pull"
+	OnExistingFetch = "fetch"
+	OnExistingError = "error"
+)
+
+const (
+	DiskSpaceWarn  = "warn"
+	DiskSpaceAbort = "abort"
+	DiskSpaceOff   = "off"
+)
+
 // LoadConfig loads configuration from the specified path with proper error handling
 func LoadConfig(path string) Config {
 	cfg := getDefaultConfig()
@@ -100,16 +239,43 @@ func SetConfigAndUpdateIcons(cfg Config) {
 
 // GetProjectsDirForUser returns the target directory for a specific user's repositories
 func GetProjectsDirForUser(username string) (string, error) {
-	projectsDir, err := expandPath(config.Repos.ProjectsDir)
+	return projectsDirForUser(config.Repos.ProjectsDir, username)
+}
+
+// GetRepoPath returns the local clone path for a repository, honouring per-repo rules and the directory layout
+func GetRepoPath(repo Repo) (string, error) {
+	projectsDir := config.Repos.ProjectsDir
+	if opts := ResolveCloneOptions(repo); opts.ProjectsDir != "" {
+		projectsDir = opts.ProjectsDir
+	}
+
+	if config.Repos.Layout != "" {
+		expanded, err := expandPath(projectsDir)
+		if err != nil {
+			return "", fmt.Errorf("failed to expand projects directory: %w", err)
+		}
+		return filepath.Join(expanded, RenderLayout(config.Repos.Layout, repo)), nil
+	}
+
+	targetDir, err := projectsDirForUser(projectsDir, repo.Owner.Login)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(targetDir, repo.Name), nil
+}
+
+// projectsDirForUser expands a projects directory and appends the user directory when enabled
+func projectsDirForUser(projectsDir, username string) (string, error) {
+	expanded, err := expandPath(projectsDir)
 	if err != nil {
 		return "", fmt.Errorf("failed to expand projects directory: %w", err)
 	}
 
 	if config.Repos.PerUserDir {
-		return filepath.Join(projectsDir, username), nil
+		return filepath.Join(expanded, username), nil
 	}
 
-	return projectsDir, nil
+	return expanded, nil
 }
 
 // getDefaultConfig returns the default configuration
@@ -132,6 +298,11 @@ func getDefaultConfig() Config {
 		Performance: PerformanceConfig{
 			RepoLimit:           "1000",
 			MaxConcurrentClones: 8,
+			CloneRetries:        2,
+			DiskSpace: DiskSpaceConfig{
+				Mode:   DiskSpaceWarn,
+				Margin: "1GB",
+			},
 			Cache: CacheConfig{
 				Repos:    "24h",
 				Readme:   "24h",
@@ -139,14 +310,32 @@ func getDefaultConfig() Config {
 			},
 		},
 		Integrations: IntegrationsConfig{
+			Bootstrap: BootstrapConfig{
+				Enabled: false,
+				Confirm: true,
+			},
+			Tmux: TmuxConfig{
+				Enabled: false,
+				Mode:    TmuxModeSession,
+				Session: "repos",
+				Switch:  true,
+			},
 			PostClone: CommandConfig{
 				Enabled: true,
 				Command: "tea",
 				Args:    []string{},
 			},
 			Git: GitConfig{
+				Protocol:   ProtocolSSH,
 				CloneDepth: 0,
 				CloneArgs:  []string{},
+				OnExisting: OnExistingSkip,
+				Forks: ForkConfig{
+					AddUpstream:    true,
+					RemoteName:     "upstream",
+					TrackUpstream:  false,
+					ShowDivergence: true,
+				},
 			},
 		},
 	}
@@ -176,6 +365,12 @@ func applyDefaults(cfg Config) Config {
 	if cfg.Performance.MaxConcurrentClones == 0 {
 		cfg.Performance.MaxConcurrentClones = defaults.Performance.MaxConcurrentClones
 	}
+	if cfg.Performance.DiskSpace.Mode == "" {
+		cfg.Performance.DiskSpace.Mode = defaults.Performance.DiskSpace.Mode
+	}
+	if cfg.Performance.DiskSpace.Margin == "" {
+		cfg.Performance.DiskSpace.Margin = defaults.Performance.DiskSpace.Margin
+	}
 	if cfg.Performance.Cache.Repos == "" {
 		cfg.Performance.Cache.Repos = defaults.Performance.Cache.Repos
 	}
@@ -186,6 +381,16 @@ func applyDefaults(cfg Config) Config {
 		cfg.Performance.Cache.Username = defaults.Performance.Cache.Username
 	}
 
+	if cfg.Integrations.Git.Protocol == "" {
+		cfg.Integrations.Git.Protocol = defaults.Integrations.Git.Protocol
+	}
+	if cfg.Integrations.Git.OnExisting == "" {
+		cfg.Integrations.Git.OnExisting = defaults.Integrations.Git.OnExisting
+	}
+	if cfg.Integrations.Git.Forks.RemoteName == "" {
+		cfg.Integrations.Git.Forks.RemoteName = defaults.Integrations.Git.Forks.RemoteName
+	}
+
 	if cfg.UI.Icons.General == nil {
 		cfg.UI.Icons = defaults.UI.Icons
 	} else {
@@ -228,9 +433,86 @@ func validateConfig(cfg Config) error {
 	if _, err := ParseTTL(cfg.Performance.Cache.Username); err != nil {
 		return fmt.Errorf("invalid performance.cache.username: %w", err)
 	}
-	if _, err := expandPath(cfg.Repos.ProjectsDir); err != nil {
+	if cfg.Performance.CloneRetries < 0 {
+		return fmt.Errorf("invalid performance.clone_retries: %d (must not be negative)", cfg.Performance.CloneRetries)
+	}
+	if err := validateDiskSpace(cfg.Performance.DiskSpace); err != nil {
+		return err
+	}
+	if err := validateRepos(cfg.Repos); err != nil {
+		return err
+	}
+	if err := validateIdentities(cfg.Identities); err != nil {
+		return err
+	}
+	if err := validateUI(cfg.UI); err != nil {
+		return err
+	}
+	if err := validateViews(cfg.Views); err != nil {
+		return err
+	}
+	return validateIntegrations(cfg.Integrations)
+}
+
+// validateRepos checks the projects directory, layout, sorting and filter settings
+func validateRepos(repos ReposConfig) error {
+	if _, err := expandPath(repos.ProjectsDir); err != nil {
 		return fmt.Errorf("invalid repos.projects_dir: %w", err)
 	}
+	if err := validateLayout(repos.Layout); err != nil {
+		return fmt.Errorf("invalid repos.layout: %w", err)
+	}
+	if _, err := ParseSortKeys(repos.SortBy); err != nil {
+		return fmt.Errorf("invalid repos.sort_by: %w", err)
+	}
+	if _, err := ParseRepoTypes(repos.RepoType); err != nil {
+		return fmt.Errorf("invalid repos.repo_type: %w", err)
+	}
+	if _, err := ParseFilter(repos.Filter); err != nil {
+		return fmt.Errorf("invalid repos.filter: %w", err)
+	}
+	return nil
+}
+
+// validateUI checks the picker, its actions and its columns
+func validateUI(ui UIConfig) error {
+	if err := validatePicker(ui.Picker); err != nil {
+		return err
+	}
+	if err := validatePickerActions(ui.Actions); err != nil {
+		return err
+	}
+	if err := validateFzf(ui.Fzf, ui.Actions); err != nil {
+		return err
+	}
+	return validatePickerColumns(ui.Columns)
+}
+
+// validateIntegrations checks the git, hook and bootstrap settings
+func validateIntegrations(integrations IntegrationsConfig) error {
+	switch strings.ToLower(integrations.Git.OnExisting) {
+	case OnExistingSkip, OnExistingPull, OnExistingFetch, OnExistingError:
+	default:
+		return fmt.Errorf("invalid integrations.git.on_existing: %q (supported: skip, pull, fetch, error)", integrations.Git.OnExisting)
+	}
+	if err := validateProtocol(integrations.Git.Protocol); err != nil {
+		return fmt.Errorf("invalid integrations.git.protocol: %w", err)
+	}
+	if err := validateCloneProfiles(integrations.Git); err != nil {
+		return err
+	}
+	if err := validateCloneRules(integrations.Git); err != nil {
+		return err
+	}
+	if err := validateHooks(integrations.Hooks); err != nil {
+		return err
+	}
+	if err := validateBootstrap(integrations.Bootstrap); err != nil {
+		return err
+	}
+	if err := validateTmux(integrations.Tmux); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DiskFreeSpace returns the free bytes on the filesystem containing a path, tests may replace it
+var DiskFreeSpace = diskFreeSpace
+
+// EstimateCloneSize sums the disk usage GitHub reports for repositories that are not cloned yet
+func EstimateCloneSize(repos []Repo) int64 {
+	var total int64
+	for _, required := range requiredSpaceByDir(repos) {
+		total += required
+	}
+	return total
+}
+
+// FindDiskSpaceShortfalls describes every target location where the repositories plus the margin do not fit
+func FindDiskSpaceShortfalls(repos []Repo) []string {
+	margin, err := ParseSize(config.Performance.DiskSpace.Margin)
+	if err != nil {
+		margin = 0
+	}
+
+	var shortfalls []string
+	for _, group := range requiredSpaceByFilesystem(repos) {
+		free, err := DiskFreeSpace(group.Dirs[0])
+		if err != nil {
+			continue
+		}
+		if group.Required+margin > free {
+			shortfalls = append(shortfalls, fmt.Sprintf("selected repositories need about %s in %s but only %s is free (margin %s)",
+				FormatSize(group.Required), strings.Join(group.Dirs, ", "), FormatSize(free), FormatSize(margin)))
+		}
+	}
+	return shortfalls
+}
+
+// filesystemUsage is the space needed by clones into directories that share a filesystem
+type filesystemUsage struct {
+	Dirs     []string
+	Required int64
+}
+
+// requiredSpaceByFilesystem sums the space needed per filesystem, so target directories on the same disk are
+// checked together; directories whose filesystem is unknown are checked alone
+func requiredSpaceByFilesystem(repos []Repo) []filesystemUsage {
+	required := requiredSpaceByDir(repos)
+	dirs := make([]string, 0, len(required))
+	for dir := range required {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var groups []filesystemUsage
+	index := make(map[string]int)
+	for _, dir := range dirs {
+		key := "dir:" + dir
+		if id, ok := filesystemID(dir); ok {
+			key = fmt.Sprintf("dev:%d", id)
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, filesystemUsage{})
+		}
+		groups[i].Dirs = append(groups[i].Dirs, dir)
+		groups[i].Required += required[dir]
+	}
+	return groups
+}
+
+// preflightDiskSpace warns about or, in abort mode, rejects clones that would not fit on disk
+func preflightDiskSpace(repos []Repo) error {
+	mode := strings.ToLower(config.Performance.DiskSpace.Mode)
+	if mode == DiskSpaceOff {
+		return nil
+	}
+
+	shortfalls := FindDiskSpaceShortfalls(repos)
+	if len(shortfalls) == 0 {
+		return nil
+	}
+
+	err := errors.New("not enough disk space: " + strings.Join(shortfalls, "; "))
+	if mode == DiskSpaceAbort && !DryRun {
+		return err
+	}
+	fmt.Printf("%s Warning: %v\n", GetIcon("disk"), err)
+	return nil
+}
+
+// requiredSpaceByDir groups the reported size of repositories that still need cloning by their nearest existing directory
+func requiredSpaceByDir(repos []Repo) map[string]int64 {
+	required := make(map[string]int64)
+	for _, repo := range repos {
+		path, err := GetRepoPath(repo)
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		required[existingAncestor(path)] += int64(repo.DiskUsage) * bytesPerKB
+	}
+	return required
+}
+
+// existingAncestor returns the closest parent of path that exists
+func existingAncestor(path string) string {
+	for {
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+}
+
+// validateDiskSpace checks the disk space mode and margin
+func validateDiskSpace(diskSpace DiskSpaceConfig) error {
+	switch strings.ToLower(diskSpace.Mode) {
+	case DiskSpaceWarn, DiskSpaceAbort, DiskSpaceOff:
+	default:
+		return fmt.Errorf("invalid performance.disk_space.mode: %q (supported: warn, abort, off)", diskSpace.Mode)
+	}
+	if _, err := ParseSize(diskSpace.Margin); err != nil {
+		return fmt.Errorf("invalid performance.disk_space.margin: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin
+
+package cmd
+
+import "errors"
+
+// diskFreeSpace is not supported on this platform, so the disk space check is skipped
+func diskFreeSpace(_ string) (int64, error) {
+	return 0, errors.New("free disk space is not available on this platform")
+}
+
+// filesystemID is not supported on this platform, so every directory is checked on its own
+func filesystemID(_ string) (uint64, bool) {
+	return 0, false
+}
@@ -0,0 +1,23 @@
+//go:build linux || darwin
+
+package cmd
+
+import "syscall"
+
+// diskFreeSpace returns the bytes available to unprivileged users on the filesystem containing path
+func diskFreeSpace(path string) (int64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return int64(stat.Bavail * uint64(stat.Bsize)), nil
+}
+
+// filesystemID returns the device number of the filesystem containing path
+func filesystemID(path string) (uint64, bool) {
+	var stat syscall.Stat_t
+	if err := syscall.Stat(path, &stat); err != nil {
+		return 0, false
+	}
+	return uint64(stat.Dev), true
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// planSingleRepo reports what cloning a repository would do without touching the filesystem or remotes
+func planSingleRepo(ctx context.Context, index int, repo Repo, totalRepos int) RepoResult {
+	prefix := fmt.Sprintf("[%d/%d]", index+1, totalRepos)
+
+	targetPath, err := GetRepoPath(repo)
+	if err != nil {
+		err = fmt.Errorf("failed to get target directory: %w", err)
+		printCloneStatus(fmt.Sprintf("%s %s %v\n", prefix, GetIcon("error"), err))
+		return RepoResult{Repo: repo, Status: StatusFailed, Err: err}
+	}
+
+	if _, err := os.Stat(targetPath); err == nil {
+		return planExistingClone(ctx, repo, targetPath, prefix)
+	}
+
+	opts := ResolveCloneOptions(repo)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s Would clone %s to %s\n", prefix, GetIcon("cloning"), repo.Name, targetPath)
+	fmt.Fprintf(&b, "      git %s\n", strings.Join(opts.GitArgs(CloneURL(repo.HTMLURL, opts.Protocol), targetPath), " "))
+	if len(opts.SparsePaths) > 0 {
+		fmt.Fprintf(&b, "      git -C %s sparse-checkout set %s\n", targetPath, strings.Join(opts.SparsePaths, " "))
+	}
+	if identity, ok := ResolveIdentity(repo.Owner.Login); ok {
+		fmt.Fprintf(&b, "      set git identity %s\n", describeIdentity(identity))
+	}
+	if forkConfig := config.Integrations.Git.Forks; repo.IsFork && forkConfig.AddUpstream {
+		fmt.Fprintf(&b, "      add the fork parent as remote %q\n", forkConfig.RemoteName)
+	}
+	printCloneStatus(b.String())
+
+	return RepoResult{Repo: repo, Path: targetPath, Status: StatusPlanned}
+}
+
+// planExistingClone reports how the on_existing policy would treat an existing target directory
+func planExistingClone(ctx context.Context, repo Repo, targetPath, prefix string) RepoResult {
+	mode := getOnExistingMode()
+	if mode == OnExistingError {
+		err := fmt.Errorf("%s already exists in %s", repo.Name, targetPath)
+		printCloneStatus(fmt.Sprintf("%s %s Would fail: %v\n", prefix, GetIcon("error"), err))
+		return RepoResult{Repo: repo, Path: targetPath, Status: StatusFailed, Err: err}
+	}
+
+	origin, err := getOriginURL(ctx, targetPath)
+	if err != nil || !RemoteMatchesRepo(origin, repo) {
+		reason := fmt.Errorf("%s exists but is not a clone of %s", targetPath, repo.HTMLURL)
+		printCloneStatus(fmt.Sprintf("%s %s Would skip: %v\n", prefix, GetIcon("error"), reason))
+		return RepoResult{Repo: repo, Path: targetPath, Status: StatusMismatch, Err: reason}
+	}
+
+	switch mode {
+	case OnExistingFetch, OnExistingPull:
+		printCloneStatus(fmt.Sprintf("%s %s Would %s existing clone of %s in %s\n", prefix, GetIcon("info"), mode, repo.Name, targetPath))
+		return RepoResult{Repo: repo, Path: targetPath, Status: StatusPlanned}
+	default:
+		printCloneStatus(fmt.Sprintf("%s %s Would skip %s, already cloned in %s\n", prefix, GetIcon("info"), repo.Name, targetPath))
+		return RepoResult{Repo: repo, Path: targetPath, Status: StatusSkipped}
+	}
+}
+
+// printPostClonePlan prints the post-clone command line that would run for each repository
+func printPostClonePlan(repos []Repo, command string, args []string) error {
+	note := ""
+	if !isCommandAvailable(command) {
+		note = " (not found in PATH)"
+	}
+
+	fmt.Printf("%s Would open selected repos in %s%s\n", GetIcon("info"), command, note)
+	for _, repo := range repos {
+		repoPath, err := GetRepoPath(repo)
+		if err != nil {
+			return fmt.Errorf("failed to get target directory for %s: %w", repo.Name, err)
+		}
+		fmt.Printf("      %s\n", strings.Join(append(append([]string{command}, args...), repoPath), " "))
+	}
+	return nil
+}
@@ -0,0 +1,451 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// RepoPredicate reports whether a repository matches a filter
+type RepoPredicate func(Repo) bool
+
+// Filter returns the repositories matching the predicate, keeping their order
+func (p RepoPredicate) Filter(repos []Repo) []Repo {
+	var filtered []Repo
+	for _, repo := range repos {
+		if p(repo) {
+			filtered = append(filtered, repo)
+		}
+	}
+	return filtered
+}
+
+// filterNow is the reference time for relative date filters, tests may replace it
+var filterNow = time.Now
+
+// ParseFilter compiles a filter expression such as `lang:go stars:>10 -is:archived (topic:cli OR topic:tui)`
+// into a predicate. Terms are ANDed unless joined by OR, and NOT, - or ! negate a term or group.
+func ParseFilter(expr string) (RepoPredicate, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return func(Repo) bool { return true }, nil
+	}
+
+	p := &filterParser{tokens: tokens}
+	predicate, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return predicate, nil
+}
+
+// tokenizeFilter splits an expression into words and parentheses, keeping quoted text together
+func tokenizeFilter(expr string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inQuotes, hasWord := false, false
+
+	flush := func() {
+		if hasWord {
+			tokens = append(tokens, current.String())
+			current.Reset()
+			hasWord = false
+		}
+	}
+
+	for _, r := range expr {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasWord = true
+		case inQuotes:
+			current.WriteRune(r)
+		case unicode.IsSpace(r):
+			flush()
+		case r == '(' || r == ')':
+			if r == '(' && hasWord && (current.String() == "-" || current.String() == "!") {
+				current.Reset()
+				hasWord = false
+				tokens = append(tokens, "NOT")
+			}
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			current.WriteRune(r)
+			hasWord = true
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	flush()
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *filterParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func isFilterKeyword(token, keyword string) bool {
+	return strings.EqualFold(token, keyword)
+}
+
+// parseOr parses terms joined by OR
+func (p *filterParser) parseOr() (RepoPredicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isFilterKeyword(p.peek(), "OR") || p.peek() == "|" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(repo Repo) bool { return l(repo) || right(repo) }
+	}
+	return left, nil
+}
+
+// parseAnd parses terms joined by AND or plain juxtaposition
+func (p *filterParser) parseAnd() (RepoPredicate, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		token := p.peek()
+		if token == "" || token == ")" || token == "|" || isFilterKeyword(token, "OR") {
+			return left, nil
+		}
+		if isFilterKeyword(token, "AND") {
+			p.pos++
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(repo Repo) bool { return l(repo) && right(repo) }
+	}
+}
+
+// parseUnary parses a negation, a parenthesised group or a single term
+func (p *filterParser) parseUnary() (RepoPredicate, error) {
+	token := p.peek()
+	if token == "" {
+		return nil, fmt.Errorf("unexpected end of filter")
+	}
+	p.pos++
+
+	switch {
+	case isFilterKeyword(token, "NOT"), token == "-", token == "!":
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(repo Repo) bool { return !inner(repo) }, nil
+	case token == "(":
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return inner, nil
+	case token == ")", token == "|", isFilterKeyword(token, "OR"), isFilterKeyword(token, "AND"):
+		return nil, fmt.Errorf("unexpected %q", token)
+	case len(token) > 1 && (token[0] == '-' || token[0] == '!'):
+		inner, err := parseFilterTerm(token[1:])
+		if err != nil {
+			return nil, err
+		}
+		return func(repo Repo) bool { return !inner(repo) }, nil
+	default:
+		return parseFilterTerm(token)
+	}
+}
+
+// parseFilterTerm compiles a single key:value term, or a bare word matched against name and description
+func parseFilterTerm(term string) (RepoPredicate, error) {
+	key, value, ok := strings.Cut(term, ":")
+	if !ok {
+		return wordPredicate(term), nil
+	}
+	if value == "" {
+		return nil, fmt.Errorf("missing value for %q", key)
+	}
+
+	switch strings.ToLower(key) {
+	case "lang", "language":
+		return func(repo Repo) bool { return strings.EqualFold(repo.PrimaryLanguage.Name, value) }, nil
+	case "owner", "user":
+		return ownerPredicate(value)
+	case "name":
+		return namePredicate(value)
+	case "topic":
+		return func(repo Repo) bool { return hasTopic(repo, value) }, nil
+	case "is":
+		return isPredicate(value)
+	case "stars":
+		return countPredicate(value, func(repo Repo) int64 { return int64(repo.StargazerCount) })
+	case "forks":
+		return countPredicate(value, func(repo Repo) int64 { return int64(repo.ForkCount) })
+	case "issues":
+		return countPredicate(value, func(repo Repo) int64 { return int64(repo.Issues.TotalCount) })
+	case "size":
+		return sizePredicate(value)
+	case "updated":
+		return timePredicate(value, func(repo Repo) time.Time { return repo.UpdatedAt })
+	case "created":
+		return timePredicate(value, func(repo Repo) time.Time { return repo.CreatedAt })
+	default:
+		return nil, fmt.Errorf("unknown filter key %q (supported: lang, owner, name, topic, is, stars, forks, issues, size, updated, created)", key)
+	}
+}
+
+// wordPredicate matches a case-insensitive word in the name or description
+func wordPredicate(word string) RepoPredicate {
+	word = strings.ToLower(word)
+	return func(repo Repo) bool {
+		return strings.Contains(strings.ToLower(repo.Name), word) || strings.Contains(strings.ToLower(repo.Description), word)
+	}
+}
+
+// ownerPredicate matches the owner login exactly, or against a glob
+func ownerPredicate(pattern string) (RepoPredicate, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return func(repo Repo) bool { return matchGlob(pattern, repo.Owner.Login) }, nil
+}
+
+// namePredicate matches the name against a glob, or as a case-insensitive substring when the value has no wildcards
+func namePredicate(pattern string) (RepoPredicate, error) {
+	if !strings.ContainsAny(pattern, "*?[") {
+		needle := strings.ToLower(pattern)
+		return func(repo Repo) bool { return strings.Contains(strings.ToLower(repo.Name), needle) }, nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return func(repo Repo) bool { return matchGlob(pattern, repo.Name) }, nil
+}
+
+// repoTypes lists the repository types accepted by --type and is: terms
+var repoTypes = []string{"archived", "forked", "internal", "private", "public", "source", "template"}
+
+// isPredicate matches repository flags such as is:archived or is:fork
+func isPredicate(value string) (RepoPredicate, error) {
+	switch strings.ToLower(value) {
+	case "archived":
+		return func(repo Repo) bool { return repo.IsArchived }, nil
+	case "fork", "forked":
+		return func(repo Repo) bool { return repo.IsFork }, nil
+	case "source":
+		return func(repo Repo) bool { return !repo.IsFork }, nil
+	case "private":
+		return func(repo Repo) bool { return repo.IsPrivate && !repo.IsInternal() }, nil
+	case "internal":
+		return func(repo Repo) bool { return repo.IsInternal() }, nil
+	case "public":
+		return func(repo Repo) bool { return !repo.IsPrivate && !repo.IsInternal() }, nil
+	case "template":
+		return func(repo Repo) bool { return repo.IsTemplate }, nil
+	default:
+		return nil, fmt.Errorf("unknown repository type %q (supported: %s)", value, strings.Join(repoTypes, ", "))
+	}
+}
+
+// ParseRepoTypes compiles a comma-separated type list such as "source,!archived"; a repository must match
+// one of the plain types, if any, and none of the types negated with ! or -
+func ParseRepoTypes(spec string) (RepoPredicate, error) {
+	var include, exclude []RepoPredicate
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		negated := strings.HasPrefix(part, "!") || strings.HasPrefix(part, "-")
+		if negated {
+			part = part[1:]
+		}
+		predicate, err := isPredicate(part)
+		if err != nil {
+			return nil, err
+		}
+		if negated {
+			exclude = append(exclude, predicate)
+		} else {
+			include = append(include, predicate)
+		}
+	}
+
+	return func(repo Repo) bool {
+		for _, predicate := range exclude {
+			if predicate(repo) {
+				return false
+			}
+		}
+		if len(include) == 0 {
+			return true
+		}
+		for _, predicate := range include {
+			if predicate(repo) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// splitComparison separates a leading comparison operator from its operand
+func splitComparison(value string) (string, string) {
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(value, op) {
+			return op, value[len(op):]
+		}
+	}
+	return "=", value
+}
+
+// compareInt builds a predicate for an operator, a bound and an optional range end
+func compareInt(op string, bound int64) func(int64) bool {
+	switch op {
+	case ">":
+		return func(v int64) bool { return v > bound }
+	case ">=":
+		return func(v int64) bool { return v >= bound }
+	case "<":
+		return func(v int64) bool { return v < bound }
+	case "<=":
+		return func(v int64) bool { return v <= bound }
+	default:
+		return func(v int64) bool { return v == bound }
+	}
+}
+
+// parseIntComparison compiles >N, >=N, <N, <=N, N and N..M comparisons using parse for the operands
+func parseIntComparison(value string, parse func(string) (int64, error)) (func(int64) bool, error) {
+	if low, high, ok := strings.Cut(value, ".."); ok {
+		lo, err := parse(low)
+		if err != nil {
+			return nil, err
+		}
+		hi, err := parse(high)
+		if err != nil {
+			return nil, err
+		}
+		return func(v int64) bool { return v >= lo && v <= hi }, nil
+	}
+
+	op, operand := splitComparison(value)
+	bound, err := parse(operand)
+	if err != nil {
+		return nil, err
+	}
+	return compareInt(op, bound), nil
+}
+
+func countPredicate(value string, field func(Repo) int64) (RepoPredicate, error) {
+	compare, err := parseIntComparison(value, func(s string) (int64, error) {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", s)
+		}
+		return n, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func(repo Repo) bool { return compare(field(repo)) }, nil
+}
+
+func sizePredicate(value string) (RepoPredicate, error) {
+	compare, err := parseIntComparison(value, ParseSize)
+	if err != nil {
+		return nil, err
+	}
+	return func(repo Repo) bool { return compare(int64(repo.DiskUsage) * bytesPerKB) }, nil
+}
+
+// timePredicate compiles date comparisons; ages such as <90d mean "less than 90 days ago",
+// while dates such as >2024-01-01 compare directly
+func timePredicate(value string, field func(Repo) time.Time) (RepoPredicate, error) {
+	op, operand := splitComparison(value)
+
+	if date, err := time.Parse("2006-01-02", operand); err == nil {
+		compare := compareInt(op, date.Unix())
+		if op == "=" {
+			return func(repo Repo) bool {
+				t := field(repo).UTC()
+				return !t.Before(date) && t.Before(date.AddDate(0, 0, 1))
+			}, nil
+		}
+		return func(repo Repo) bool { return compare(field(repo).Unix()) }, nil
+	}
+
+	age, err := parseAge(operand)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q (use YYYY-MM-DD or an age such as 90d, 2w, 1y)", operand)
+	}
+	compare := compareInt(op, int64(age))
+	if op == "=" {
+		compare = compareInt("<=", int64(age))
+	}
+	return func(repo Repo) bool { return compare(int64(filterNow().Sub(field(repo)))) }, nil
+}
+
+// parseAge parses ages in hours, days, weeks or years
+func parseAge(value string) (time.Duration, error) {
+	if len(value) < 2 {
+		return 0, fmt.Errorf("invalid age %q", value)
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid age %q", value)
+	}
+
+	day := 24 * time.Hour
+	switch value[len(value)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * day, nil
+	case 'w':
+		return time.Duration(n) * 7 * day, nil
+	case 'y':
+		return time.Duration(n) * 365 * day, nil
+	default:
+		return 0, fmt.Errorf("invalid age unit in %q (supported: h, d, w, y)", value)
+	}
+}
+
+// shellQuote quotes a value for use as a single argument in a POSIX shell command line
+func shellQuote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n'\"\\$`!*?[](){}<>|&;#~") {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
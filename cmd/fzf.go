@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// reservedFzfOptions are set by the picker to read fzf's output and cannot be changed with ui.fzf.extra_args
+var reservedFzfOptions = []string{"--delimiter", "--expect", "--filter", "--print-query", "--print0", "--read0", "--with-nth"}
+
+// fzfShortOptions maps the short forms of reserved options to their long names
+var fzfShortOptions = map[string]string{"-d": "--delimiter", "-f": "--filter"}
+
+// BuildFzfArgs returns the fzf arguments for the picker. FZF_DEFAULT_OPTS is read by fzf itself, so only the
+// options the picker needs and those set in ui.fzf are passed, leaving the rest of the user's defaults in place
+func BuildFzfArgs(user string, expect []string) []string {
+	fzf := config.UI.Fzf
+	reloadCmd := BuildReloadCommand(user)
+
+	args := []string{
+		"--delimiter", pickerDelimiter,
+		"--with-nth", "2..",
+		"--preview", buildPreviewCommand(user),
+		"--bind", "ctrl-r:reload(" + reloadCmd + ")",
+	}
+	if fzf.Multi == nil || *fzf.Multi {
+		args = append(args, "--multi")
+	} else {
+		args = append(args, "--no-multi")
+	}
+	if len(config.Views) > 0 {
+		args = append(args,
+			"--bind", "ctrl-v:transform-header("+buildCycleViewCommand()+")+reload("+reloadCmd+")",
+			"--header", ViewHeader(View),
+		)
+	} else {
+		args = append(args, "--header", "Press Ctrl+r to refresh repositories")
+	}
+	if len(expect) > 0 {
+		args = append(args, "--expect", strings.Join(expect, ","))
+	}
+
+	if fzf.PreviewWindow != "" {
+		args = append(args, "--preview-window", fzf.PreviewWindow)
+	}
+	if fzf.Colors != "" {
+		args = append(args, "--color", fzf.Colors)
+	}
+	keys := make([]string, 0, len(fzf.Binds))
+	for key := range fzf.Binds {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "--bind", key+":"+fzf.Binds[key])
+	}
+	return append(args, fzf.ExtraArgs...)
+}
+
+// validateFzf checks that the fzf key bindings and extra arguments leave the keys and options the picker relies on alone
+func validateFzf(fzf FzfConfig, actions map[string]string) error {
+	for key, action := range fzf.Binds {
+		if key == "" || strings.ContainsAny(key, ", :\t") {
+			return fmt.Errorf("invalid ui.fzf.binds key %q", key)
+		}
+		if slices.Contains(reservedPickerKeys, key) {
+			return fmt.Errorf("invalid ui.fzf.binds key %q: reserved by the picker (%s)", key, strings.Join(reservedPickerKeys, ", "))
+		}
+		if _, ok := actions[key]; ok {
+			return fmt.Errorf("invalid ui.fzf.binds key %q: already bound to ui.actions.%s", key, key)
+		}
+		if strings.TrimSpace(action) == "" {
+			return fmt.Errorf("invalid ui.fzf.binds.%s: action cannot be empty", key)
+		}
+	}
+	for _, arg := range fzf.ExtraArgs {
+		if name := fzfOptionName(arg); slices.Contains(reservedFzfOptions, name) {
+			return fmt.Errorf("invalid ui.fzf.extra_args entry %q: %s is set by the picker", arg, name)
+		}
+	}
+	return nil
+}
+
+// fzfOptionName returns the long name of the option an fzf argument sets, recognising values attached with = and
+// short forms such as -d: whose value follows the letter
+func fzfOptionName(arg string) string {
+	arg = strings.TrimSpace(arg)
+	if strings.HasPrefix(arg, "--") {
+		name, _, _ := strings.Cut(arg, "=")
+		return name
+	}
+	if len(arg) >= 2 && arg[0] == '-' {
+		if name, ok := fzfShortOptions[arg[:2]]; ok {
+			return name
+		}
+		return arg[:2]
+	}
+	return arg
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// RemoteRef identifies a repository by host, owner and name
+type RemoteRef struct {
+	Host  string
+	Owner string
+	Name  string
+}
+
+// FullName returns the owner/name form of the reference
+func (r RemoteRef) FullName() string {
+	return r.Owner + "/" + r.Name
+}
+
+// ParseRemoteURL extracts host, owner and name from HTTPS, SSH and scp-style git URLs
+func ParseRemoteURL(remote string) (RemoteRef, bool) {
+	remote = strings.TrimSpace(remote)
+	var hostAndPath string
+
+	switch {
+	case strings.Contains(remote, "://"):
+		hostAndPath = remote[strings.Index(remote, "://")+3:]
+		if at := strings.Index(hostAndPath, "@"); at >= 0 && at < strings.Index(hostAndPath+"/", "/") {
+			hostAndPath = hostAndPath[at+1:]
+		}
+	case strings.Contains(remote, "@") && strings.Contains(remote, ":"):
+		hostAndPath = strings.Replace(remote[strings.Index(remote, "@")+1:], ":", "/", 1)
+	default:
+		return RemoteRef{}, false
+	}
+
+	parts := strings.Split(strings.Trim(hostAndPath, "/"), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return RemoteRef{}, false
+	}
+
+	host := parts[0]
+	if colon := strings.Index(host, ":"); colon >= 0 {
+		host = host[:colon]
+	}
+
+	return RemoteRef{Host: host, Owner: parts[1], Name: strings.TrimSuffix(parts[2], ".git")}, true
+}
+
+// RemoteMatchesRepo reports whether a git remote URL points at the given repository
+func RemoteMatchesRepo(remote string, repo Repo) bool {
+	remoteRef, ok := ParseRemoteURL(remote)
+	if !ok {
+		return false
+	}
+	repoRef, ok := ParseRemoteURL(repo.HTMLURL)
+	if !ok {
+		return false
+	}
+
+	return strings.EqualFold(remoteRef.Host, repoRef.Host) &&
+		strings.EqualFold(remoteRef.Owner, repoRef.Owner) &&
+		strings.EqualFold(remoteRef.Name, repoRef.Name)
+}
+
+// runCommandContext runs cmd to completion, killing it if ctx is cancelled first
+func runCommandContext(ctx context.Context, cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			if cmd.Process != nil {
+				if killErr := cmd.Process.Kill(); killErr != nil {
+					fmt.Fprintf(os.Stderr, "Warning: Failed to kill process: %v\n", killErr)
+				}
+			}
+		}
+	}()
+
+	return cmd.Wait()
+}
+
+// runGit runs a git command inside dir and returns its trimmed standard output
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := ExecCommand("git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := runCommandContext(ctx, cmd); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("git %s cancelled: %w", args[0], ctx.Err())
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s failed: %s", args[0], msg)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// getOriginURL returns the URL of the origin remote of a local clone
+func getOriginURL(ctx context.Context, path string) (string, error) {
+	return runGit(ctx, path, "remote", "get-url", "origin")
+}
+
+// getDefaultBranch returns the branch origin/HEAD points at in a local clone
+func getDefaultBranch(ctx context.Context, path string) (string, error) {
+	ref, err := runGit(ctx, path, "symbolic-ref", "--short", "refs/remotes/origin/HEAD")
+	if err != nil || ref == "" {
+		return "", fmt.Errorf("cannot determine default branch, run 'git remote set-head origin --auto' in %s", path)
+	}
+	return strings.TrimPrefix(ref, "origin/"), nil
+}
+
+// getCurrentBranch returns the checked out branch of a local clone
+func getCurrentBranch(ctx context.Context, path string) (string, error) {
+	return runGit(ctx, path, "rev-parse", "--abbrev-ref", "HEAD")
+}
+
+// fastForwardDefaultBranch fast-forwards the local default branch to origin without touching other branches
+// and reports whether the branch moved
+func fastForwardDefaultBranch(ctx context.Context, path string) (bool, error) {
+	branch, err := getDefaultBranch(ctx, path)
+	if err != nil {
+		return false, err
+	}
+
+	current, err := getCurrentBranch(ctx, path)
+	if err != nil {
+		return false, err
+	}
+
+	before, _ := runGit(ctx, path, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	if current == branch {
+		_, err = runGit(ctx, path, "merge", "--ff-only", "origin/"+branch)
+	} else {
+		_, err = runGit(ctx, path, "fetch", "origin", branch+":"+branch)
+	}
+	if err != nil {
+		return false, err
+	}
+	after, _ := runGit(ctx, path, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+
+	return before != after, nil
+}
+
+// isWorkingTreeDirty reports whether a local clone has uncommitted changes
+func isWorkingTreeDirty(ctx context.Context, path string) (bool, error) {
+	out, err := runGit(ctx, path, "status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+// isGitRepo reports whether path is the root of a git working tree
+func isGitRepo(path string) bool {
+	_, err := os.Stat(filepath.Join(path, ".git"))
+	return err == nil
+}
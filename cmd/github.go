@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"os/exec"
 	"strings"
@@ -39,35 +44,63 @@ func ValidateUsername(username string) error {
 
 // GetRepos fetches repositories for a user with stale-while-revalidate caching for instant startup
 func GetRepos(user string) ([]Repo, error) {
-	if RefreshCache {
-		return forceFetchRepos(user)
-	}
-
-	cachedRepos, loadErr := LoadReposFromCache(user)
-	if loadErr == nil && len(cachedRepos) > 0 {
-		// Always rehydrate cache in background on startup for instant UI responsiveness
-		go func(u string) {
-			ctx, cancel := context.WithTimeout(context.Background(), DefaultContextTimeout)
-			defer cancel()
-			if repos, err := GetReposWithContext(ctx, u); err == nil {
-				_ = SaveReposToCache(u, repos)
-			}
-		}(user)
-		return cachedRepos, nil
+	return getCachedRepos(user, func() ([]Repo, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultContextTimeout)
+		defer cancel()
+		return GetReposWithContext(ctx, user)
+	})
+}
+
+// GetStarredRepos fetches the repositories starred by a user, or by the current user when user is empty
+func GetStarredRepos(user string) ([]Repo, error) {
+	if err := ValidateUsername(user); err != nil {
+		return nil, fmt.Errorf("invalid username: %w", err)
 	}
+	endpoint := "user/starred"
+	if user != "" {
+		endpoint = fmt.Sprintf("users/%s/starred", user)
+	}
+	return getCachedRepos("starred@"+user, func() ([]Repo, error) {
+		return ghAPIRepos(endpoint + "?per_page=100")
+	})
+}
 
-	return forceFetchRepos(user)
+// SearchRepos fetches the repositories matching a GitHub search query
+func SearchRepos(query string) ([]Repo, error) {
+	sum := sha256.Sum256([]byte(query))
+	return getCachedRepos("search@"+hex.EncodeToString(sum[:8]), func() ([]Repo, error) {
+		var result struct {
+			Items []restRepo `json:"items"`
+		}
+		if err := ghAPI(&result, "search/repositories?per_page=100&q="+url.QueryEscape(query)); err != nil {
+			return nil, err
+		}
+		return convertRESTRepos(result.Items), nil
+	})
 }
 
-func forceFetchRepos(user string) ([]Repo, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), DefaultContextTimeout)
-	defer cancel()
-	repos, err := GetReposWithContext(ctx, user)
+// getCachedRepos returns cached repositories instantly and revalidates them in the background, fetching directly
+// when nothing is cached or a refresh is forced
+func getCachedRepos(cacheKey string, fetch func() ([]Repo, error)) ([]Repo, error) {
+	if !RefreshCache {
+		cachedRepos, loadErr := LoadReposFromCache(cacheKey)
+		if loadErr == nil && len(cachedRepos) > 0 {
+			// Always rehydrate cache in background on startup for instant UI responsiveness
+			go func() {
+				if repos, err := fetch(); err == nil {
+					_ = SaveReposToCache(cacheKey, repos)
+				}
+			}()
+			return cachedRepos, nil
+		}
+	}
+
+	repos, err := fetch()
 	if err != nil {
 		return nil, err
 	}
 
-	if err := SaveReposToCache(user, repos); err != nil {
+	if err := SaveReposToCache(cacheKey, repos); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: Failed to save repos to cache: %v\n", err)
 	}
 
@@ -202,6 +235,202 @@ func GetReadme(repoFullName string) (string, error) {
 	return content, nil
 }
 
+// ForkParent describes the repository a fork was created from
+type ForkParent struct {
+	FullName      string `json:"full_name"`
+	HTMLURL       string `json:"html_url"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// ForkInfo holds the fork's own default branch and its parent repository
+type ForkInfo struct {
+	DefaultBranch string      `json:"default_branch"`
+	Parent        *ForkParent `json:"parent"`
+}
+
+// ForkDivergence counts commits the fork is ahead of and behind its parent
+type ForkDivergence struct {
+	AheadBy  int `json:"ahead_by"`
+	BehindBy int `json:"behind_by"`
+}
+
+// GetForkInfo fetches the default branch and parent repository of a fork
+func GetForkInfo(repoFullName string) (ForkInfo, error) {
+	var info ForkInfo
+	if err := ghAPI(&info, fmt.Sprintf("repos/%s", repoFullName)); err != nil {
+		return ForkInfo{}, err
+	}
+	if info.Parent == nil {
+		return ForkInfo{}, fmt.Errorf("%s has no parent repository", repoFullName)
+	}
+	return info, nil
+}
+
+// GetForkDivergence compares a fork's default branch with its parent's default branch
+func GetForkDivergence(repo Repo) (ForkParent, ForkDivergence, error) {
+	info, err := GetForkInfo(repo.FullName())
+	if err != nil {
+		return ForkParent{}, ForkDivergence{}, err
+	}
+
+	var divergence ForkDivergence
+	basehead := fmt.Sprintf("%s...%s:%s", info.Parent.DefaultBranch, repo.Owner.Login, info.DefaultBranch)
+	if err := ghAPI(&divergence, fmt.Sprintf("repos/%s/compare/%s", info.Parent.FullName, basehead)); err != nil {
+		return *info.Parent, ForkDivergence{}, err
+	}
+
+	return *info.Parent, divergence, nil
+}
+
+// GetCachedForkDivergence returns a fork's divergence from the cache, refreshing it in the background once it
+// is older than the README cache TTL and fetching it when nothing is cached
+func GetCachedForkDivergence(repo Repo) (ForkParent, ForkDivergence, error) {
+	ttl, err := ParseTTL(config.Performance.Cache.Readme)
+	if err != nil {
+		ttl = 24 * time.Hour
+	}
+
+	if cachePath, err := getForkDivergenceCachePath(repo.Owner.Login, repo.Name); err == nil {
+		fresh := IsCacheValid(cachePath, ttl)
+		parent, divergence, loadErr := LoadForkDivergenceFromCache(repo.Owner.Login, repo.Name)
+		if loadErr == nil {
+			if !fresh {
+				go func() { _ = refreshForkDivergence(repo) }()
+			}
+			return parent, divergence, nil
+		}
+	}
+
+	parent, divergence, err := GetForkDivergence(repo)
+	if err != nil {
+		return parent, divergence, err
+	}
+	if err := SaveForkDivergenceToCache(repo.Owner.Login, repo.Name, parent, divergence); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to save fork divergence to cache: %v\n", err)
+	}
+	return parent, divergence, nil
+}
+
+// refreshForkDivergence fetches a fork's divergence and replaces the cached one
+func refreshForkDivergence(repo Repo) error {
+	parent, divergence, err := GetForkDivergence(repo)
+	if err != nil {
+		return err
+	}
+	return SaveForkDivergenceToCache(repo.Owner.Login, repo.Name, parent, divergence)
+}
+
+// ghAPI calls a GitHub REST endpoint through gh and decodes the JSON response into v
+func ghAPI(v any, endpoint string) error {
+	out, err := ghAPIOutput(endpoint)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(out, v); err != nil {
+		return fmt.Errorf("failed to parse gh api %s response: %w", endpoint, err)
+	}
+	return nil
+}
+
+// ghAPIRepos fetches every page of a GitHub REST endpoint that lists repositories
+func ghAPIRepos(endpoint string) ([]Repo, error) {
+	out, err := ghAPIOutput(endpoint, "--paginate")
+	if err != nil {
+		return nil, err
+	}
+
+	// --paginate prints one JSON array per page
+	repos := []Repo{}
+	decoder := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var page []restRepo
+		if err := decoder.Decode(&page); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse gh api %s response: %w", endpoint, err)
+		}
+		repos = append(repos, convertRESTRepos(page)...)
+	}
+	return repos, nil
+}
+
+// ghAPIOutput runs gh api for an endpoint and returns the raw response
+func ghAPIOutput(endpoint string, extraArgs ...string) ([]byte, error) {
+	cmd := ExecCommand("gh", append([]string{"api", endpoint}, extraArgs...)...)
+	if len(cmd.Env) == 0 {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, "GH_PROMPT_DISABLED=1")
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("gh api %s failed: %s", endpoint, strings.TrimSpace(string(exitError.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to execute gh api command: %w", err)
+	}
+	return out, nil
+}
+
+// restRepo is a repository as returned by the GitHub REST API
+type restRepo struct {
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	HTMLURL         string    `json:"html_url"`
+	StargazersCount int       `json:"stargazers_count"`
+	ForksCount      int       `json:"forks_count"`
+	WatchersCount   int       `json:"watchers_count"`
+	OpenIssuesCount int       `json:"open_issues_count"`
+	Owner           Owner     `json:"owner"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	PushedAt        time.Time `json:"pushed_at"`
+	Size            int       `json:"size"`
+	Homepage        string    `json:"homepage"`
+	Fork            bool      `json:"fork"`
+	Archived        bool      `json:"archived"`
+	Private         bool      `json:"private"`
+	IsTemplate      bool      `json:"is_template"`
+	Visibility      string    `json:"visibility"`
+	Topics          []string  `json:"topics"`
+	Language        string    `json:"language"`
+}
+
+// convertRESTRepos converts REST API repositories to the fields gh repo list returns
+func convertRESTRepos(restRepos []restRepo) []Repo {
+	repos := make([]Repo, 0, len(restRepos))
+	for _, r := range restRepos {
+		topics := make([]Topic, 0, len(r.Topics))
+		for _, topic := range r.Topics {
+			topics = append(topics, Topic{Name: topic})
+		}
+		repos = append(repos, Repo{
+			Name:            r.Name,
+			Description:     r.Description,
+			HTMLURL:         r.HTMLURL,
+			StargazerCount:  r.StargazersCount,
+			ForkCount:       r.ForksCount,
+			Watchers:        Count{TotalCount: r.WatchersCount},
+			Issues:          Count{TotalCount: r.OpenIssuesCount},
+			Owner:           r.Owner,
+			CreatedAt:       r.CreatedAt,
+			UpdatedAt:       r.UpdatedAt,
+			PushedAt:        r.PushedAt,
+			DiskUsage:       r.Size,
+			HomepageURL:     r.Homepage,
+			IsFork:          r.Fork,
+			IsArchived:      r.Archived,
+			IsPrivate:       r.Private,
+			IsTemplate:      r.IsTemplate,
+			Visibility:      strings.ToUpper(r.Visibility),
+			Topics:          topics,
+			PrimaryLanguage: Language{Name: r.Language},
+		})
+	}
+	return repos
+}
+
 // buildRepoListArgs builds command arguments for fetching repositories
 func buildRepoListArgs(user string) []string {
 	repoLimit := config.Performance.RepoLimit
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	HookFailureContinue = "continue"
+	HookFailureStop     = "stop"
+	HookFailureAbort    = "abort"
+)
+
+// hookPathsPlaceholder expands into one argument per repository path for hooks that run once
+const hookPathsPlaceholder = "{paths}"
+
+// RunHooks runs the configured hooks in order for the cloned repositories and returns the repositories
+// whose pipeline was not stopped by a failing hook
+func RunHooks(repos []Repo, hooks []HookConfig) ([]Repo, error) {
+	active := append([]Repo(nil), repos...)
+
+	for _, hook := range hooks {
+		matching := filterHookRepos(active, hook)
+		if len(matching) == 0 {
+			continue
+		}
+
+		if hook.Once {
+			if err := runHook(hook, matching, Repo{}); err != nil {
+				switch hookFailurePolicy(hook) {
+				case HookFailureAbort:
+					return nil, fmt.Errorf("hook %s failed: %w", hookName(hook), err)
+				case HookFailureStop:
+					active = withoutRepos(active, matching)
+				}
+			}
+			continue
+		}
+
+		for _, repo := range matching {
+			if err := runHook(hook, nil, repo); err != nil {
+				switch hookFailurePolicy(hook) {
+				case HookFailureAbort:
+					return nil, fmt.Errorf("hook %s failed for %s: %w", hookName(hook), repo.Name, err)
+				case HookFailureStop:
+					active = withoutRepos(active, []Repo{repo})
+				}
+			}
+		}
+	}
+
+	return active, nil
+}
+
+// runHook runs a hook for a single repository, or once for all repositories when repo is empty
+func runHook(hook HookConfig, repos []Repo, repo Repo) error {
+	values, paths, err := hookValues(repo, repos)
+	if err != nil {
+		return err
+	}
+
+	command := os.ExpandEnv(ExpandHookTemplate(hook.Command, values))
+	args := expandHookArgs(hook.Args, values, paths)
+	dir := hook.Dir
+	if dir == "" && hook.Once {
+		dir = "."
+	} else if dir == "" {
+		dir = "{path}"
+	}
+	dir, err = expandPath(ExpandHookTemplate(dir, values))
+	if err != nil {
+		return fmt.Errorf("invalid hook directory: %w", err)
+	}
+
+	label := repo.Name
+	if hook.Once {
+		label = fmt.Sprintf("%d repositories", len(repos))
+	}
+
+	if DryRun {
+		fmt.Printf("%s Would run hook %s for %s in %s\n      %s\n", GetIcon("info"), hookName(hook), label, dir, strings.Join(append([]string{command}, args...), " "))
+		return nil
+	}
+
+	fmt.Printf("%s Running hook %s for %s\n", GetIcon("info"), hookName(hook), label)
+	cmd := ExecCommand(command, args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	if err := cmd.Run(); err != nil {
+		fmt.Printf("%s Hook %s failed for %s: %v\n", GetIcon("error"), hookName(hook), label, err)
+		return err
+	}
+	return nil
+}
+
+// hookValues returns the template values for a repository, or the paths of all repositories when running once
+func hookValues(repo Repo, repos []Repo) (map[string]string, []string, error) {
+	if len(repos) > 0 {
+		paths := make([]string, 0, len(repos))
+		for _, r := range repos {
+			p, err := GetRepoPath(r)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get target directory for %s: %w", r.Name, err)
+			}
+			paths = append(paths, p)
+		}
+		return map[string]string{"paths": strings.Join(paths, " ")}, paths, nil
+	}
+
+	repoPath, err := GetRepoPath(repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get target directory for %s: %w", repo.Name, err)
+	}
+	values := map[string]string{
+		"path":     repoPath,
+		"paths":    repoPath,
+		"name":     repo.Name,
+		"owner":    repo.Owner.Login,
+		"language": repo.PrimaryLanguage.Name,
+		"url":      repo.HTMLURL,
+	}
+	return values, []string{repoPath}, nil
+}
+
+// ExpandHookTemplate replaces {path}, {name}, {owner}, {language}, {url} and {paths} in s
+func ExpandHookTemplate(s string, values map[string]string) string {
+	for _, key := range []string{"path", "paths", "name", "owner", "language", "url"} {
+		s = strings.ReplaceAll(s, "{"+key+"}", values[key])
+	}
+	return s
+}
+
+// expandHookArgs expands templated arguments, turning a bare {paths} argument into one argument per path
+func expandHookArgs(args []string, values map[string]string, paths []string) []string {
+	expanded := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == hookPathsPlaceholder {
+			expanded = append(expanded, paths...)
+			continue
+		}
+		expanded = append(expanded, ExpandHookTemplate(arg, values))
+	}
+	return expanded
+}
+
+// filterHookRepos returns the repositories satisfying the hook's when conditions
+func filterHookRepos(repos []Repo, hook HookConfig) []Repo {
+	var matching []Repo
+	for _, repo := range repos {
+		if hook.When.Matches(repo) {
+			matching = append(matching, repo)
+		}
+	}
+	return matching
+}
+
+// withoutRepos returns repos minus the removed ones
+func withoutRepos(repos, removed []Repo) []Repo {
+	var kept []Repo
+	for _, repo := range repos {
+		found := false
+		for _, r := range removed {
+			if r.FullName() == repo.FullName() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			kept = append(kept, repo)
+		}
+	}
+	return kept
+}
+
+func hookName(hook HookConfig) string {
+	if hook.Name != "" {
+		return hook.Name
+	}
+	return path.Base(hook.Command)
+}
+
+func hookFailurePolicy(hook HookConfig) string {
+	if hook.OnFailure == "" {
+		return HookFailureContinue
+	}
+	return strings.ToLower(hook.OnFailure)
+}
+
+// validateHooks checks that every hook has a command, a known failure policy and valid conditions
+func validateHooks(hooks []HookConfig) error {
+	for i, hook := range hooks {
+		if strings.TrimSpace(hook.Command) == "" {
+			return fmt.Errorf("integrations.hooks[%d] has no command", i)
+		}
+		switch hookFailurePolicy(hook) {
+		case HookFailureContinue, HookFailureStop, HookFailureAbort:
+		default:
+			return fmt.Errorf("invalid integrations.hooks[%d].on_failure: %q (supported: continue, stop, abort)", i, hook.OnFailure)
+		}
+		for _, pattern := range []string{hook.When.Owner, hook.When.Name} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid integrations.hooks[%d].when pattern %q: %w", i, pattern, err)
+			}
+		}
+	}
+	return nil
+}
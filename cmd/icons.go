@@ -4,8 +4,10 @@ import "strings"
 
 var GeneralIcons = map[string]string{
 	"archived": " ",
+	"branch":   " ",
 	"calendar": " ",
 	"clock":    " ",
+	"cloned":   " ",
 	"cloning":  " ",
 	"disk":     " ",
 	"done":     " ",
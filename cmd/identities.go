@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var identitiesDryRun bool
+
+var IdentitiesCmd = &cobra.Command{
+	Use:   "identities",
+	Short: "Manage per-owner git identities of local clones",
+}
+
+var IdentitiesApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply the configured git identities to existing clones",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runIdentitiesApply(cmd.Context(), os.Stdout, identitiesDryRun); err != nil {
+			fmt.Println(err)
+			os.Exit(ExitCodeFor(err))
+		}
+	},
+}
+
+func init() {
+	IdentitiesApplyCmd.Flags().BoolVarP(&identitiesDryRun, "dry-run", "n", false, "Show which identities would be applied without changing any clone")
+	IdentitiesCmd.AddCommand(IdentitiesApplyCmd)
+	rootCmd.AddCommand(IdentitiesCmd)
+}
+
+// IdentityResult is the outcome of applying an identity to one local clone
+type IdentityResult struct {
+	Path     string
+	Owner    string
+	Identity *IdentityConfig
+	Err      error
+}
+
+// ResolveIdentity returns the first identity whose owners match the repository owner
+func ResolveIdentity(owner string) (IdentityConfig, bool) {
+	for _, identity := range config.Identities {
+		for _, pattern := range identity.Owners {
+			if matchGlob(pattern, owner) {
+				return identity, true
+			}
+		}
+	}
+	return IdentityConfig{}, false
+}
+
+// IdentityGitConfig returns the git config key and value pairs an identity sets
+func IdentityGitConfig(identity IdentityConfig) [][2]string {
+	var settings [][2]string
+	if identity.Name != "" {
+		settings = append(settings, [2]string{"user.name", identity.Name})
+	}
+	if identity.Email != "" {
+		settings = append(settings, [2]string{"user.email", identity.Email})
+	}
+	if identity.SigningKey != "" {
+		settings = append(settings, [2]string{"user.signingkey", identity.SigningKey}, [2]string{"commit.gpgsign", "true"})
+	}
+	if identity.SSHKey != "" {
+		settings = append(settings, [2]string{"core.sshCommand", identitySSHCommand(identity)})
+	}
+	return settings
+}
+
+// identitySSHCommand returns the ssh command that authenticates with the identity's key
+func identitySSHCommand(identity IdentityConfig) string {
+	key, err := expandPath(identity.SSHKey)
+	if err != nil {
+		key = identity.SSHKey
+	}
+	return fmt.Sprintf("ssh -i %s -o IdentitiesOnly=yes", shellQuote(key))
+}
+
+// IdentityCloneArgs returns the git options that make a clone of the owner's repository use its identity's SSH key,
+// which the local config written after the clone cannot do for the clone itself
+func IdentityCloneArgs(owner string) []string {
+	identity, ok := ResolveIdentity(owner)
+	if !ok || identity.SSHKey == "" {
+		return nil
+	}
+	return []string{"-c", "core.sshCommand=" + identitySSHCommand(identity)}
+}
+
+// applyIdentity writes the identity matching the owner into the local config of a clone
+func applyIdentity(ctx context.Context, owner, targetPath string) (*IdentityConfig, error) {
+	identity, ok := ResolveIdentity(owner)
+	if !ok {
+		return nil, nil
+	}
+	for _, setting := range IdentityGitConfig(identity) {
+		if _, err := runGit(ctx, targetPath, "config", "--local", setting[0], setting[1]); err != nil {
+			return &identity, err
+		}
+	}
+	return &identity, nil
+}
+
+// runIdentitiesApply applies the configured identities to every clone under the projects directories
+func runIdentitiesApply(ctx context.Context, w io.Writer, dryRun bool) error {
+	if len(config.Identities) == 0 {
+		fmt.Fprintln(w, "No identities configured.")
+		return nil
+	}
+
+	var paths []string
+	for _, root := range statusRoots() {
+		found, err := FindLocalClones(root)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, found...)
+	}
+
+	results := ApplyIdentities(ctx, paths, dryRun)
+	PrintIdentityResults(w, results, dryRun)
+
+	for _, result := range results {
+		if result.Err != nil {
+			return &ExitError{Code: ExitCodePartialFailure, Err: fmt.Errorf("some identities could not be applied")}
+		}
+	}
+	return nil
+}
+
+// ApplyIdentities matches clones to identities by the owner of their origin remote and applies them
+func ApplyIdentities(ctx context.Context, paths []string, dryRun bool) []IdentityResult {
+	results := make([]IdentityResult, 0, len(paths))
+	for _, clonePath := range paths {
+		result := IdentityResult{Path: clonePath}
+
+		origin, err := getOriginURL(ctx, clonePath)
+		if err != nil {
+			results = append(results, result)
+			continue
+		}
+		ref, ok := ParseRemoteURL(origin)
+		if !ok {
+			results = append(results, result)
+			continue
+		}
+		result.Owner = ref.Owner
+
+		if dryRun {
+			if identity, ok := ResolveIdentity(ref.Owner); ok {
+				result.Identity = &identity
+			}
+		} else {
+			result.Identity, result.Err = applyIdentity(ctx, ref.Owner, clonePath)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// PrintIdentityResults prints the identity applied to each clone followed by a summary
+func PrintIdentityResults(w io.Writer, results []IdentityResult, dryRun bool) {
+	verb := "Applied"
+	if dryRun {
+		verb = "Would apply"
+	}
+
+	applied, failed := 0, 0
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			failed++
+			fmt.Fprintf(w, "%s %s: %v\n", GetIcon("error"), displayClonePath(result.Path), result.Err)
+		case result.Identity != nil:
+			applied++
+			fmt.Fprintf(w, "%s %s %s to %s\n", GetIcon("success"), verb, describeIdentity(*result.Identity), displayClonePath(result.Path))
+		}
+	}
+
+	fmt.Fprintf(w, "%d clones: %d with identity, %d without, %d failed\n", len(results), applied, len(results)-applied-failed, failed)
+}
+
+// describeIdentity formats an identity as "Name <email>"
+func describeIdentity(identity IdentityConfig) string {
+	switch {
+	case identity.Name != "" && identity.Email != "":
+		return fmt.Sprintf("%s <%s>", identity.Name, identity.Email)
+	case identity.Email != "":
+		return "<" + identity.Email + ">"
+	case identity.Name != "":
+		return identity.Name
+	default:
+		return "identity for " + strings.Join(identity.Owners, ", ")
+	}
+}
+
+// validateIdentities checks that every identity has owners and something to set
+func validateIdentities(identities []IdentityConfig) error {
+	for i, identity := range identities {
+		if len(identity.Owners) == 0 {
+			return fmt.Errorf("identities[%d] has no owners", i)
+		}
+		for _, pattern := range identity.Owners {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid identities[%d] owner pattern %q: %w", i, pattern, err)
+			}
+		}
+		if len(IdentityGitConfig(identity)) == 0 {
+			return fmt.Errorf("identities[%d] sets none of name, email, signing_key or ssh_key", i)
+		}
+	}
+	return nil
+}
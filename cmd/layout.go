@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	defaultLayoutHost     = "github.com"
+	missingLayoutValue    = "none"
+	layoutNamePlaceholder = "{name}"
+)
+
+var (
+	layoutPlaceholderRegex = regexp.MustCompile(`\{([a-z]+[0-9]*)\}`)
+	layoutTopicRegex       = regexp.MustCompile(`^topic[0-9]+$`)
+)
+
+// layoutValues returns the placeholder values a repository provides to a directory layout
+func layoutValues(repo Repo) map[string]string {
+	host := defaultLayoutHost
+	if ref, ok := ParseRemoteURL(repo.HTMLURL); ok {
+		host = ref.Host
+	}
+
+	values := map[string]string{
+		"host":     host,
+		"owner":    repo.Owner.Login,
+		"name":     repo.Name,
+		"language": repo.PrimaryLanguage.Name,
+	}
+	for i, topic := range repo.TopicNames() {
+		values[fmt.Sprintf("topic%d", i)] = topic
+	}
+	return values
+}
+
+// RenderLayout expands a layout template such as {host}/{owner}/{name} into a relative path for a repository
+func RenderLayout(layout string, repo Repo) string {
+	values := layoutValues(repo)
+	rendered := layoutPlaceholderRegex.ReplaceAllStringFunc(layout, func(placeholder string) string {
+		value := sanitizeLayoutValue(values[strings.Trim(placeholder, "{}")])
+		if value == "" {
+			return missingLayoutValue
+		}
+		return value
+	})
+	return filepath.FromSlash(rendered)
+}
+
+// layoutNeedsMetadata reports whether a layout uses values that are only known from the GitHub API
+func layoutNeedsMetadata(layout string) bool {
+	for _, match := range layoutPlaceholderRegex.FindAllStringSubmatch(layout, -1) {
+		if match[1] == "language" || strings.HasPrefix(match[1], "topic") {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeLayoutValue keeps a placeholder value from introducing extra path segments
+func sanitizeLayoutValue(value string) string {
+	value = strings.NewReplacer("/", "-", "\\", "-").Replace(strings.TrimSpace(value))
+	if value == "." || value == ".." {
+		return ""
+	}
+	return value
+}
+
+// validateLayout checks that a layout is relative, names the repository and only uses known placeholders
+func validateLayout(layout string) error {
+	if layout == "" {
+		return nil
+	}
+	if filepath.IsAbs(layout) || strings.HasPrefix(layout, "~") {
+		return fmt.Errorf("layout %q must be relative to projects_dir", layout)
+	}
+	if !strings.Contains(layout, layoutNamePlaceholder) {
+		return fmt.Errorf("layout %q must contain %s", layout, layoutNamePlaceholder)
+	}
+	for _, segment := range strings.Split(filepath.ToSlash(layout), "/") {
+		if segment == ".." {
+			return fmt.Errorf("layout %q must not leave projects_dir", layout)
+		}
+	}
+	for _, match := range layoutPlaceholderRegex.FindAllStringSubmatch(layout, -1) {
+		switch {
+		case match[1] == "host", match[1] == "owner", match[1] == "name", match[1] == "language":
+		case layoutTopicRegex.MatchString(match[1]):
+		default:
+			return fmt.Errorf("unknown layout placeholder %s (supported: {host}, {owner}, {name}, {language}, {topic0}...)", match[0])
+		}
+	}
+	return nil
+}
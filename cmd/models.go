@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -32,18 +33,20 @@ type Repo struct {
 	Owner           Owner     `json:"owner"`
 	CreatedAt       time.Time `json:"createdAt"`
 	UpdatedAt       time.Time `json:"updatedAt"`
+	PushedAt        time.Time `json:"pushedAt"`
 	DiskUsage       int       `json:"diskUsage"`
 	HomepageURL     string    `json:"homepageUrl"`
 	IsFork          bool      `json:"isFork"`
 	IsArchived      bool      `json:"isArchived"`
 	IsPrivate       bool      `json:"isPrivate"`
 	IsTemplate      bool      `json:"isTemplate"`
+	Visibility      string    `json:"visibility"`
 	Topics          []Topic   `json:"repositoryTopics"`
 	PrimaryLanguage Language  `json:"primaryLanguage"`
 }
 
 const (
-	JSONFields            = "name,description,url,stargazerCount,forkCount,watchers,issues,owner,createdAt,updatedAt,diskUsage,homepageUrl,isFork,isArchived,isPrivate,isTemplate,repositoryTopics,primaryLanguage"
+	JSONFields            = "name,description,url,stargazerCount,forkCount,watchers,issues,owner,createdAt,updatedAt,pushedAt,diskUsage,homepageUrl,isFork,isArchived,isPrivate,isTemplate,visibility,repositoryTopics,primaryLanguage"
 	DefaultRepoLimit      = "1000"
 	MaxUsernameLength     = 39
 	MinUsernameLength     = 1
@@ -54,6 +57,25 @@ const (
 
 var usernameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9\-_]*[a-zA-Z0-9])?$`)
 
+// FullName returns the owner/name identifier of the repository
+func (r *Repo) FullName() string {
+	return r.Owner.Login + "/" + r.Name
+}
+
+// LastPushed returns when the repository was last pushed to, falling back to its last update for
+// repositories cached before the push time was fetched
+func (r *Repo) LastPushed() time.Time {
+	if r.PushedAt.IsZero() {
+		return r.UpdatedAt
+	}
+	return r.PushedAt
+}
+
+// IsInternal reports whether the repository is visible to every member of its enterprise
+func (r *Repo) IsInternal() bool {
+	return strings.EqualFold(r.Visibility, "internal")
+}
+
 // TopicNames extracts topic names as strings
 func (r *Repo) TopicNames() []string {
 	names := make([]string, len(r.Topics))
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	CloneStateCloned    = "cloned"
+	CloneStateNotCloned = "not-cloned"
+)
+
+// pickerDelimiter separates the selection key from the text shown in the picker
+const pickerDelimiter = "\t"
+
+// IsCloned reports whether the repository has a git clone at its resolved target path
+func IsCloned(repo Repo) bool {
+	path, err := GetRepoPath(repo)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(filepath.Join(path, ".git"))
+	return err == nil && info.IsDir()
+}
+
+// PickerColumns are the columns the picker can show for each repository
+var PickerColumns = []string{"badges", "cloned", "description", "forks", "icon", "issues", "language", "name", "owner", "pushed", "stars", "updated"}
+
+// DefaultPickerColumns are the columns shown when ui.columns is not configured
+var DefaultPickerColumns = []string{"cloned", "icon", "name", "stars", "pushed", "badges"}
+
+// rightAlignedColumns hold numbers and ages, which line up on their last digit
+var rightAlignedColumns = map[string]bool{"forks": true, "issues": true, "pushed": true, "stars": true, "updated": true}
+
+// countColumnIcons are shown in front of the aligned numbers of count columns
+var countColumnIcons = map[string]string{"forks": "fork", "issues": "issue", "stars": "star"}
+
+// FormatPickerLine returns the picker entry for a repository: its owner/name key, then its visible columns
+func FormatPickerLine(repo Repo) string {
+	return FormatPickerLines([]Repo{repo})[0]
+}
+
+// FormatPickerLines returns the picker entries for repositories in order, with every visible column padded to
+// the same width
+func FormatPickerLines(repos []Repo) []string {
+	columns := pickerColumns()
+	multipleOwners := hasMultipleOwners(repos)
+
+	cells := make([][]string, len(repos))
+	widths := make([]int, len(columns))
+	for i, repo := range repos {
+		cells[i] = make([]string, len(columns))
+		for j, column := range columns {
+			cells[i][j] = pickerCell(repo, column, multipleOwners)
+			widths[j] = max(widths[j], utf8.RuneCountInString(cells[i][j]))
+		}
+	}
+
+	lines := make([]string, 0, len(repos))
+	for i, repo := range repos {
+		var b strings.Builder
+		for j, cell := range cells[i] {
+			if j > 0 {
+				b.WriteString("  ")
+			}
+			if icon, ok := countColumnIcons[columns[j]]; ok {
+				b.WriteString(GetIcon(icon))
+			}
+			padding := strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell))
+			if rightAlignedColumns[columns[j]] {
+				b.WriteString(padding + cell)
+			} else {
+				b.WriteString(cell + padding)
+			}
+		}
+		lines = append(lines, repo.FullName()+pickerDelimiter+strings.TrimRight(b.String(), " "))
+	}
+	return lines
+}
+
+// pickerCell renders one column of a repository's picker entry
+func pickerCell(repo Repo, column string, multipleOwners bool) string {
+	switch column {
+	case "badges":
+		return pickerBadges(repo)
+	case "cloned":
+		if IsCloned(repo) {
+			return GetIcon("cloned")
+		}
+		return strings.Repeat(" ", utf8.RuneCountInString(GetIcon("cloned")))
+	case "description":
+		return repo.Description
+	case "forks":
+		return FormatCount(repo.ForkCount)
+	case "icon":
+		return GetLanguageIcon(repo.PrimaryLanguage.Name)
+	case "issues":
+		return FormatCount(repo.Issues.TotalCount)
+	case "language":
+		return repo.PrimaryLanguage.Name
+	case "name":
+		if multipleOwners {
+			return repo.FullName()
+		}
+		return repo.Name
+	case "owner":
+		return repo.Owner.Login
+	case "pushed":
+		return FormatAge(repo.LastPushed())
+	case "stars":
+		return FormatCount(repo.StargazerCount)
+	case "updated":
+		return FormatAge(repo.UpdatedAt)
+	default:
+		return ""
+	}
+}
+
+// pickerBadges marks forked, archived, private and template repositories
+func pickerBadges(repo Repo) string {
+	var badges []string
+	for _, badge := range []struct {
+		set  bool
+		icon string
+	}{
+		{repo.IsFork, "fork"},
+		{repo.IsArchived, "archived"},
+		{repo.IsPrivate, "private"},
+		{repo.IsTemplate, "template"},
+	} {
+		if badge.set {
+			badges = append(badges, GetIcon(badge.icon))
+		}
+	}
+	return strings.Join(badges, "")
+}
+
+// hasMultipleOwners reports whether the repositories belong to more than one owner
+func hasMultipleOwners(repos []Repo) bool {
+	for _, repo := range repos {
+		if repo.Owner.Login != repos[0].Owner.Login {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatCount abbreviates large counts, e.g. 1234 as 1.2k
+func FormatCount(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/1_000_000), ".0") + "m"
+	case n >= 1_000:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/1_000), ".0") + "k"
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+// FormatAge describes how long ago t was in its largest whole unit, e.g. 3d ago
+func FormatAge(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	age := filterNow().Sub(t)
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", max(int(age/time.Minute), 0))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age/time.Hour))
+	case age < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(age/(24*time.Hour)))
+	case age < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(age/(30*24*time.Hour)))
+	default:
+		return fmt.Sprintf("%dy ago", int(age/(365*24*time.Hour)))
+	}
+}
+
+// ParsePickerLine returns the owner/name key from a selected picker entry
+func ParsePickerLine(line string) string {
+	key, _, _ := strings.Cut(line, pickerDelimiter)
+	return strings.TrimSpace(key)
+}
+
+// SelectPickedRepos returns the repositories whose owner/name keys were picked, in the order they were picked
+func SelectPickedRepos(repos []Repo, keys []string) []Repo {
+	byKey := make(map[string]Repo, len(repos))
+	for _, repo := range repos {
+		byKey[repo.FullName()] = repo
+	}
+
+	var picked []Repo
+	for _, key := range keys {
+		if repo, ok := byKey[key]; ok {
+			picked = append(picked, repo)
+		}
+	}
+	return picked
+}
+
+// pickerColumns returns the configured picker columns, or the defaults when none are set
+func pickerColumns() []string {
+	if len(config.UI.Columns) == 0 {
+		return DefaultPickerColumns
+	}
+	return config.UI.Columns
+}
+
+// validatePickerColumns checks that every configured picker column is supported
+func validatePickerColumns(columns []string) error {
+	for _, column := range columns {
+		if !slices.Contains(PickerColumns, column) {
+			return fmt.Errorf("invalid ui.columns entry %q (supported: %s)", column, strings.Join(PickerColumns, ", "))
+		}
+	}
+	return nil
+}
+
+// matchesCloneState reports whether the repository's local clone state matches cloned or not-cloned
+func matchesCloneState(repo Repo, state string) bool {
+	switch state {
+	case CloneStateCloned:
+		return IsCloned(repo)
+	case CloneStateNotCloned:
+		return !IsCloned(repo)
+	default:
+		return true
+	}
+}
+
+// buildLocalPreview describes the branch, working tree and last commit of a cloned repository
+func buildLocalPreview(repo Repo) string {
+	if !IsCloned(repo) {
+		return ""
+	}
+	path, err := GetRepoPath(repo)
+	if err != nil {
+		return ""
+	}
+
+	status := GetLocalRepoStatus(context.Background(), path)
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n%s Cloned: %s\n", GetIcon("cloned"), path)
+	if status.Error != "" {
+		fmt.Fprintf(&b, "%s Local status unavailable: %s\n", GetIcon("error"), status.Error)
+		return b.String()
+	}
+
+	state := "clean"
+	if status.IsDirty() {
+		state = fmt.Sprintf("%d uncommitted changes", status.Dirty)
+	}
+	fmt.Fprintf(&b, "%s Branch: %s (%s)\n", GetIcon("branch"), status.Branch, state)
+	if status.LastCommit != "" {
+		fmt.Fprintf(&b, "%s Last local commit: %s\n", GetIcon("clock"), status.LastCommit)
+	}
+	return b.String()
+}
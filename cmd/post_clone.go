@@ -4,38 +4,94 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
+	"reflect"
 )
 
-// HandlePostClone handles post-cloning actions (tea integration or editor fallback)
+// HandlePostClone runs the hook pipeline and then opens the repositories in tmux or with the post-clone command
 func HandlePostClone(repos []Repo) error {
 	if len(repos) == 0 {
 		return nil
 	}
-	cmdConfig := config.Integrations.PostClone
 
-	if cmdConfig.Enabled {
-		return OpenWithCommand(repos, cmdConfig)
+	repos, err := RunHooks(repos, config.Integrations.Hooks)
+	if err != nil {
+		return err
+	}
+
+	return openRepos(repos)
+}
+
+// openRepos opens local clones in tmux or with their post-clone command
+func openRepos(repos []Repo) error {
+	if config.Integrations.Tmux.Enabled {
+		if !DryRun && !isCommandAvailable("tmux") {
+			return fmt.Errorf("command tmux is not available in PATH")
+		}
+		return OpenInTmux(repos)
+	}
+
+	for _, group := range groupByPostClone(repos) {
+		if !group.command.Enabled {
+			if DryRun {
+				fmt.Printf("%s Post-clone command disabled for %d repositories\n", GetIcon("info"), len(group.repos))
+			}
+			continue
+		}
+		if err := OpenWithCommand(group.repos, group.command); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+type postCloneGroup struct {
+	command CommandConfig
+	repos   []Repo
+}
+
+// groupByPostClone groups repositories by their effective post-clone command, keeping selection order
+func groupByPostClone(repos []Repo) []postCloneGroup {
+	var groups []postCloneGroup
+	for _, repo := range repos {
+		command := config.Integrations.PostClone
+		if override := ResolveCloneOptions(repo).PostClone; override != nil {
+			command = *override
+		}
+
+		found := false
+		for i := range groups {
+			if reflect.DeepEqual(groups[i].command, command) {
+				groups[i].repos = append(groups[i].repos, repo)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, postCloneGroup{command: command, repos: []Repo{repo}})
+		}
+	}
+	return groups
+}
+
 // OpenWithCommand opens repositories with the configured command
 func OpenWithCommand(repos []Repo, cmdConfig CommandConfig) error {
 	command := os.ExpandEnv(cmdConfig.Command)
 
+	if DryRun {
+		return printPostClonePlan(repos, command, cmdConfig.Args)
+	}
+
 	if !isCommandAvailable(command) {
 		return fmt.Errorf("command %s is not available in PATH", command)
 	}
 
 	fmt.Printf("%s Opening selected repos in %s\n", GetIcon("info"), command)
 	for _, repo := range repos {
-		targetDir, err := GetProjectsDirForUser(repo.Owner.Login)
+		repoPath, err := GetRepoPath(repo)
 		if err != nil {
 			return fmt.Errorf("failed to get target directory for %s: %w", repo.Name, err)
 		}
-		repoPath := filepath.Join(targetDir, repo.Name)
 
 		args := append(append([]string(nil), cmdConfig.Args...), repoPath)
 		cmd := ExecCommand(command, args...)
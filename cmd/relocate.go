@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	relocateUser   string
+	relocateDryRun bool
+)
+
+var RelocateCmd = &cobra.Command{
+	Use:   "relocate",
+	Short: "Move existing clones into the configured directory layout",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runRelocate(cmd.Context(), os.Stdout, relocateUser, relocateDryRun); err != nil {
+			fmt.Println(err)
+			os.Exit(ExitCodeFor(err))
+		}
+	},
+}
+
+func init() {
+	RelocateCmd.Flags().StringVarP(&relocateUser, "user", "u", "", "The user whose repositories to look up for layout metadata")
+	RelocateCmd.Flags().BoolVarP(&relocateDryRun, "dry-run", "n", false, "Show where clones would move without moving them")
+	rootCmd.AddCommand(RelocateCmd)
+}
+
+// Relocation describes moving a local clone to the path the layout expects
+type Relocation struct {
+	From   string
+	To     string
+	Reason string
+	Err    error
+}
+
+// NeedsMove reports whether the clone should be moved
+func (r Relocation) NeedsMove() bool {
+	return r.Err == nil && r.Reason == "" && r.From != r.To
+}
+
+// runRelocate plans and applies moves for every clone under the projects directories
+func runRelocate(ctx context.Context, w io.Writer, user string, dryRun bool) error {
+	repos, err := GetRepos(user)
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+	for _, root := range statusRoots() {
+		found, err := FindLocalClones(root)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, found...)
+	}
+
+	relocations := PlanRelocations(ctx, paths, repos)
+	if !dryRun {
+		relocations = ApplyRelocations(relocations)
+	}
+	PrintRelocations(w, relocations, dryRun)
+
+	for _, relocation := range relocations {
+		if relocation.Err != nil {
+			return &ExitError{Code: ExitCodePartialFailure, Err: fmt.Errorf("some clones could not be relocated")}
+		}
+	}
+	return nil
+}
+
+// PlanRelocations matches clones to repositories by their origin remote and computes their layout paths
+func PlanRelocations(ctx context.Context, paths []string, repos []Repo) []Relocation {
+	known := make(map[string]Repo, len(repos))
+	for _, repo := range repos {
+		known[strings.ToLower(repo.FullName())] = repo
+	}
+
+	relocations := make([]Relocation, 0, len(paths))
+	for _, path := range paths {
+		relocations = append(relocations, planRelocation(ctx, path, known))
+	}
+	return relocations
+}
+
+// planRelocation resolves the layout path of a single clone
+func planRelocation(ctx context.Context, path string, known map[string]Repo) Relocation {
+	relocation := Relocation{From: path, To: path}
+
+	origin, err := getOriginURL(ctx, path)
+	if err != nil {
+		relocation.Reason = "no origin remote"
+		return relocation
+	}
+	ref, ok := ParseRemoteURL(origin)
+	if !ok {
+		relocation.Reason = "unrecognised origin " + origin
+		return relocation
+	}
+
+	repo, ok := known[strings.ToLower(ref.FullName())]
+	if !ok {
+		if layoutNeedsMetadata(config.Repos.Layout) {
+			relocation.Reason = "unknown repository " + ref.FullName()
+			return relocation
+		}
+		repo = Repo{Name: ref.Name, Owner: Owner{Login: ref.Owner}, HTMLURL: fmt.Sprintf("https://%s/%s/%s", ref.Host, ref.Owner, ref.Name)}
+	}
+
+	target, err := GetRepoPath(repo)
+	if err != nil {
+		relocation.Err = err
+		return relocation
+	}
+	relocation.To = target
+
+	if target != path {
+		if _, err := os.Stat(target); err == nil {
+			relocation.Reason = "target already exists"
+		}
+	}
+	return relocation
+}
+
+// ApplyRelocations moves clones to their planned paths and removes directories left empty
+func ApplyRelocations(relocations []Relocation) []Relocation {
+	roots := statusRoots()
+	for i, relocation := range relocations {
+		if !relocation.NeedsMove() {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(relocation.To), 0o750); err != nil {
+			relocations[i].Err = fmt.Errorf("failed to create %s: %w", filepath.Dir(relocation.To), err)
+			continue
+		}
+		if err := os.Rename(relocation.From, relocation.To); err != nil {
+			relocations[i].Err = fmt.Errorf("failed to move %s: %w", relocation.From, err)
+			continue
+		}
+		removeEmptyParents(filepath.Dir(relocation.From), roots)
+	}
+	return relocations
+}
+
+// removeEmptyParents deletes empty directories upwards while they are inside one of the roots
+func removeEmptyParents(dir string, roots []string) {
+	for isInsideRoot(dir, roots) {
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// isInsideRoot reports whether dir is below one of the roots, not the root itself
+func isInsideRoot(dir string, roots []string) bool {
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, dir)
+		if err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintRelocations writes the planned or applied moves as an aligned table
+func PrintRelocations(w io.Writer, relocations []Relocation, dryRun bool) {
+	moved, inPlace, skipped := 0, 0, 0
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, relocation := range relocations {
+		switch {
+		case relocation.Err != nil:
+			skipped++
+			fmt.Fprintf(tw, "%s\t%s\t%v\n", GetIcon("error"), relocation.From, relocation.Err)
+		case relocation.Reason != "":
+			skipped++
+			fmt.Fprintf(tw, "%s\t%s\tskipped: %s\n", GetIcon("info"), relocation.From, relocation.Reason)
+		case relocation.From == relocation.To:
+			inPlace++
+		default:
+			moved++
+			fmt.Fprintf(tw, "%s\t%s\t-> %s\n", GetIcon("success"), relocation.From, relocation.To)
+		}
+	}
+	_ = tw.Flush()
+
+	verb := "moved"
+	if dryRun {
+		verb = "would move"
+	}
+	fmt.Fprintf(w, "%d clones: %d %s, %d already in place, %d skipped\n", len(relocations), moved, verb, inPlace, skipped)
+}
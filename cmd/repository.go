@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"cmp"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
+	"time"
 )
 
 // BuildRepoMap creates a name-to-repo lookup map
@@ -43,6 +46,9 @@ func BuildRepoPreview(repo Repo) string {
 	}
 	if repo.IsFork {
 		b.WriteString(fmt.Sprintf("\n%s Forked\n", GetIcon("fork")))
+		if config.Integrations.Git.Forks.ShowDivergence {
+			b.WriteString(buildForkDivergence(repo))
+		}
 	}
 	if repo.IsArchived {
 		b.WriteString(fmt.Sprintf("\n%s Archived\n", GetIcon("archived")))
@@ -56,6 +62,7 @@ func BuildRepoPreview(repo Repo) string {
 	if len(repo.Topics) > 0 {
 		b.WriteString(fmt.Sprintf("\n%s Topics: %s\n", GetIcon("tag"), strings.Join(repo.TopicNames(), ", ")))
 	}
+	b.WriteString(buildLocalPreview(repo))
 
 	if config.UI.ShowReadmeInPreview {
 		b.WriteString("\n---\n")
@@ -73,6 +80,19 @@ func BuildRepoPreview(repo Repo) string {
 	return b.String()
 }
 
+// buildForkDivergence describes how far a fork has drifted from its parent
+func buildForkDivergence(repo Repo) string {
+	parent, divergence, err := GetCachedForkDivergence(repo)
+	if err != nil {
+		if parent.FullName == "" {
+			return fmt.Sprintf("%s Fork divergence unavailable: %s\n", GetIcon("info"), err)
+		}
+		return fmt.Sprintf("%s Parent: %s (divergence unavailable)\n", GetIcon("owner"), parent.FullName)
+	}
+
+	return fmt.Sprintf("%s Parent: %s (%d ahead, %d behind)\n", GetIcon("owner"), parent.FullName, divergence.AheadBy, divergence.BehindBy)
+}
+
 // SelectReposByNames filters repositories by name using map lookup
 func SelectReposByNames(repoMap map[string]Repo, selectedNames []string) []Repo {
 	var selectedRepos []Repo
@@ -86,90 +106,137 @@ func SelectReposByNames(repoMap map[string]Repo, selectedNames []string) []Repo
 	return selectedRepos
 }
 
-// FilterRepositories filters repositories based on type and language
-func FilterRepositories(repos []Repo, repoType, language string) []Repo {
-	if repoType == "" && language == "" {
+// FilterRepositories filters repositories based on type, language and local clone state, unknown types match nothing
+func FilterRepositories(repos []Repo, repoType, language, cloneState string) []Repo {
+	if repoType == "" && language == "" && cloneState == "" {
 		return repos
 	}
 
+	matchesType, err := ParseRepoTypes(repoType)
+	if err != nil {
+		return nil
+	}
+
 	var filtered []Repo
 	for _, repo := range repos {
-		// Filter by type
-		if repoType != "" {
-			switch strings.ToLower(repoType) {
-			case "archived":
-				if !repo.IsArchived {
-					continue
-				}
-			case "forked":
-				if !repo.IsFork {
-					continue
-				}
-			case "private":
-				if !repo.IsPrivate {
-					continue
-				}
-			case "template":
-				if !repo.IsTemplate {
-					continue
-				}
-			default:
-			}
+		if !matchesType(repo) {
+			continue
 		}
-
 		if language != "" && !strings.EqualFold(repo.PrimaryLanguage.Name, language) {
 			continue
 		}
-
+		if !matchesCloneState(repo, cloneState) {
+			continue
+		}
 		filtered = append(filtered, repo)
 	}
 
 	return filtered
 }
 
-// SortRepositories sorts repositories based on the specified criteria
+// sortKeyCompare compares two repositories by one sort key in ascending order
+type sortKeyCompare func(a, b Repo) int
+
+// sortKeys maps sort keys to their ascending comparison and whether they sort descending by default
+var sortKeys = map[string]struct {
+	compare    sortKeyCompare
+	descending bool
+}{
+	"created":   {func(a, b Repo) int { return a.CreatedAt.Compare(b.CreatedAt) }, true},
+	"forks":     {func(a, b Repo) int { return cmp.Compare(a.ForkCount, b.ForkCount) }, true},
+	"issues":    {func(a, b Repo) int { return cmp.Compare(a.Issues.TotalCount, b.Issues.TotalCount) }, true},
+	"language":  {func(a, b Repo) int { return compareFold(a.PrimaryLanguage.Name, b.PrimaryLanguage.Name) }, false},
+	"name":      {func(a, b Repo) int { return compareFold(a.Name, b.Name) }, false},
+	"pushed":    {func(a, b Repo) int { return a.LastPushed().Compare(b.LastPushed()) }, true},
+	"relevance": {func(a, b Repo) int { return cmp.Compare(RelevanceScore(a), RelevanceScore(b)) }, true},
+	"size":      {func(a, b Repo) int { return cmp.Compare(a.DiskUsage, b.DiskUsage) }, true},
+	"stars":     {func(a, b Repo) int { return cmp.Compare(a.StargazerCount, b.StargazerCount) }, true},
+	"updated":   {func(a, b Repo) int { return a.UpdatedAt.Compare(b.UpdatedAt) }, true},
+}
+
+// relevanceHalfLife is how long it takes for the recency part of the relevance score to halve
+const relevanceHalfLife = 90 * 24 * time.Hour
+
+// RelevanceScore blends the star count with how recently the repository was updated
+func RelevanceScore(repo Repo) float64 {
+	age := filterNow().Sub(repo.UpdatedAt)
+	if age < 0 {
+		age = 0
+	}
+	recency := math.Pow(0.5, float64(age)/float64(relevanceHalfLife))
+	return math.Log10(float64(repo.StargazerCount)+1) + 2*recency
+}
+
+func compareFold(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// ParseSortKeys compiles a comma-separated sort specification such as "language,-stars,name" into a comparison;
+// a + or - prefix sorts a key ascending or descending, otherwise counts and dates sort descending and text ascending
+func ParseSortKeys(sortBy string) (sortKeyCompare, error) {
+	var compares []sortKeyCompare
+	for _, part := range strings.Split(sortBy, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		name := strings.TrimLeft(part, "+-")
+		key, ok := sortKeys[name]
+		if !ok || len(part)-len(name) > 1 {
+			return nil, fmt.Errorf("unknown sort key %q (supported: %s)", part, strings.Join(sortKeyNames(), ", "))
+		}
+
+		descending := key.descending
+		switch part[0] {
+		case '+':
+			descending = false
+		case '-':
+			descending = true
+		}
+
+		compare := key.compare
+		if descending {
+			compare = func(a, b Repo) int { return key.compare(b, a) }
+		}
+		compares = append(compares, compare)
+	}
+
+	return func(a, b Repo) int {
+		for _, compare := range compares {
+			if c := compare(a, b); c != 0 {
+				return c
+			}
+		}
+		return compareFold(a.FullName(), b.FullName())
+	}, nil
+}
+
+func sortKeyNames() []string {
+	names := make([]string, 0, len(sortKeys))
+	for name := range sortKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SortRepositories stably sorts repositories by the comma-separated sort keys, breaking ties by owner/name;
+// an invalid specification leaves the order unchanged
 func SortRepositories(repos []Repo, sortBy string) []Repo {
 	if sortBy == "" {
 		return repos
 	}
 
-	sorted := make([]Repo, len(repos))
-	copy(sorted, repos)
-
-	switch strings.ToLower(sortBy) {
-	case "created":
-		sort.Slice(sorted, func(i, j int) bool {
-			return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
-		})
-	case "forks":
-		sort.Slice(sorted, func(i, j int) bool {
-			return sorted[i].ForkCount > sorted[j].ForkCount
-		})
-	case "issues":
-		sort.Slice(sorted, func(i, j int) bool {
-			return sorted[i].Issues.TotalCount > sorted[j].Issues.TotalCount
-		})
-	case "language":
-		sort.Slice(sorted, func(i, j int) bool {
-			return strings.ToLower(sorted[i].PrimaryLanguage.Name) < strings.ToLower(sorted[j].PrimaryLanguage.Name)
-		})
-	case "name":
-		sort.Slice(sorted, func(i, j int) bool {
-			return strings.ToLower(sorted[i].Name) < strings.ToLower(sorted[j].Name)
-		})
-	case "pushed", "updated":
-		sort.Slice(sorted, func(i, j int) bool {
-			return sorted[i].UpdatedAt.After(sorted[j].UpdatedAt)
-		})
-	case "size":
-		sort.Slice(sorted, func(i, j int) bool {
-			return sorted[i].DiskUsage > sorted[j].DiskUsage
-		})
-	case "stars":
-		sort.Slice(sorted, func(i, j int) bool {
-			return sorted[i].StargazerCount > sorted[j].StargazerCount
-		})
+	compare, err := ParseSortKeys(sortBy)
+	if err != nil {
+		return repos
 	}
 
+	sorted := make([]Repo, len(repos))
+	copy(sorted, repos)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compare(sorted[i], sorted[j]) < 0
+	})
 	return sorted
 }
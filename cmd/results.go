@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// ResultStatus describes the outcome of an operation on a single repository
+type ResultStatus string
+
+const (
+	StatusCloned    ResultStatus = "cloned"
+	StatusSkipped   ResultStatus = "skipped"
+	StatusUpdated   ResultStatus = "updated"
+	StatusUpToDate  ResultStatus = "up-to-date"
+	StatusDirty     ResultStatus = "dirty"
+	StatusMismatch  ResultStatus = "mismatch"
+	StatusFailed    ResultStatus = "failed"
+	StatusCancelled ResultStatus = "cancelled"
+	StatusPlanned   ResultStatus = "planned"
+)
+
+const (
+	ExitCodeError          = 1
+	ExitCodePartialFailure = 2
+	ExitCodeTotalFailure   = 3
+	ExitCodeInterrupted    = 130
+)
+
+// RepoResult records what happened to a single repository
+type RepoResult struct {
+	Repo   Repo
+	Path   string
+	Status ResultStatus
+	Err    error
+}
+
+// RepoResults is the aggregated outcome of a multi-repository operation
+type RepoResults []RepoResult
+
+// ExitError carries a specific process exit code along with the underlying error
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCodeFor returns the process exit code that should be used for an error
+func ExitCodeFor(err error) int {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitCodeError
+}
+
+// IsFailure reports whether the status represents a failed or aborted operation, or a directory that holds
+// a different repository
+func (s ResultStatus) IsFailure() bool {
+	return s == StatusFailed || s == StatusCancelled || s == StatusMismatch
+}
+
+// IsAvailable reports whether the repository is usable locally after the operation
+func (s ResultStatus) IsAvailable() bool {
+	return s == StatusCloned || s == StatusSkipped || s == StatusUpdated || s == StatusDirty || s == StatusPlanned
+}
+
+// Succeeded returns the repositories that are available locally after the operation
+func (r RepoResults) Succeeded() []Repo {
+	var repos []Repo
+	for _, result := range r {
+		if result.Status.IsAvailable() {
+			repos = append(repos, result.Repo)
+		}
+	}
+	return repos
+}
+
+// Count returns the number of results with the given status
+func (r RepoResults) Count(status ResultStatus) int {
+	count := 0
+	for _, result := range r {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// WithStatus returns the repositories whose result has the given status
+func (r RepoResults) WithStatus(status ResultStatus) []Repo {
+	var repos []Repo
+	for _, result := range r {
+		if result.Status == status {
+			repos = append(repos, result.Repo)
+		}
+	}
+	return repos
+}
+
+// Err joins the errors of all failed, cancelled and mismatched results
+func (r RepoResults) Err() error {
+	var errs []error
+	for _, result := range r {
+		if result.Status.IsFailure() && result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ExitCode returns 0 when nothing failed, a partial failure code when some repos failed
+// and a total failure code when none succeeded
+func (r RepoResults) ExitCode() int {
+	failures := 0
+	for _, result := range r {
+		if result.Status.IsFailure() {
+			failures++
+		}
+	}
+
+	switch {
+	case failures == 0:
+		return 0
+	case failures == len(r):
+		return ExitCodeTotalFailure
+	default:
+		return ExitCodePartialFailure
+	}
+}
+
+// statusIcon returns the icon used for a status in summaries
+func statusIcon(status ResultStatus) string {
+	switch status {
+	case StatusCloned, StatusUpdated, StatusUpToDate:
+		return GetIcon("success")
+	case StatusSkipped, StatusDirty, StatusPlanned:
+		return GetIcon("info")
+	default:
+		return GetIcon("error")
+	}
+}
+
+// PrintResultSummary writes a per-repository summary table followed by status totals
+func PrintResultSummary(w io.Writer, results RepoResults) {
+	if len(results) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\nSummary:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, result := range results {
+		detail := result.Path
+		if result.Err != nil {
+			detail = result.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s %s\t%s\t%s\n", statusIcon(result.Status), result.Status, result.Repo.Name, detail)
+	}
+	_ = tw.Flush()
+
+	var counts []string
+	for _, status := range []ResultStatus{StatusCloned, StatusSkipped, StatusUpdated, StatusUpToDate, StatusDirty, StatusMismatch, StatusFailed, StatusCancelled, StatusPlanned} {
+		if n := results.Count(status); n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, status))
+		}
+	}
+	fmt.Fprintf(w, "%d repositories: %s\n", len(results), strings.Join(counts, ", "))
+}
+
+// PrintInterruptedSummary lists which repositories finished and which were aborted by an interrupt
+func PrintInterruptedSummary(w io.Writer, results RepoResults) {
+	var finished, aborted []string
+	for _, result := range results {
+		if result.Status == StatusCancelled {
+			aborted = append(aborted, result.Repo.Name)
+		} else {
+			finished = append(finished, result.Repo.Name)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%s Interrupted: %d finished, %d aborted\n", GetIcon("error"), len(finished), len(aborted))
+	if len(finished) > 0 {
+		fmt.Fprintf(w, "Finished: %s\n", strings.Join(finished, ", "))
+	}
+	if len(aborted) > 0 {
+		fmt.Fprintf(w, "Aborted:  %s\n", strings.Join(aborted, ", "))
+	}
+}
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 )
@@ -25,9 +28,13 @@ var (
 	User           string
 	RepoType       string
 	LanguageFilter string
+	Filter         string
 	SortBy         string
 	ProjectsDir    string
 	RefreshCache   bool
+	DryRun         bool
+	ClonedOnly     bool
+	NotClonedOnly  bool
 )
 
 var (
@@ -44,10 +51,13 @@ var rootCmd = &cobra.Command{
 		}
 	},
 	Run: func(cmd *cobra.Command, args []string) {
-		err := runMain()
+		err := resolveView(cmd.Flags().Changed)
+		if err == nil {
+			err = runMain(cmd.Context())
+		}
 		if err != nil {
 			fmt.Println(err)
-			os.Exit(1)
+			os.Exit(ExitCodeFor(err))
 		}
 	},
 }
@@ -65,7 +75,12 @@ var PreviewCmd = &cobra.Command{
 			targetUser = User
 		}
 
-		repos, err := GetRepos(targetUser)
+		if err := resolveView(cmd.Flags().Changed); err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		repos, err := fetchRepos(targetUser)
 		if err != nil {
 			fmt.Println("Error fetching repos for preview:", err)
 			return
@@ -90,6 +105,9 @@ var ListCmd = &cobra.Command{
 		if targetUser == "" {
 			targetUser = User
 		}
+		if err := resolveView(cmd.Flags().Changed); err != nil {
+			return err
+		}
 
 		oldRefresh := RefreshCache
 		RefreshCache = true
@@ -100,8 +118,8 @@ var ListCmd = &cobra.Command{
 			return err
 		}
 
-		for _, name := range extractRepoNames(sortedRepos) {
-			fmt.Println(name)
+		for _, line := range FormatPickerLines(sortedRepos) {
+			fmt.Println(line)
 		}
 		return nil
 	},
@@ -135,8 +153,19 @@ func Execute() {
 	if LanguageFilter == "" {
 		LanguageFilter = config.Repos.Language
 	}
+	if Filter == "" {
+		Filter = config.Repos.Filter
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		// restore default handling so a second signal terminates immediately
+		stop()
+	}()
 
-	if err := rootCmd.Execute(); err != nil {
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -145,24 +174,38 @@ func Execute() {
 func init() {
 	rootCmd.Flags().StringVarP(&User, "user", "u", "", "The user to fetch repositories for.")
 	rootCmd.Flags().StringVarP(&configPath, "config", "c", DefaultConfigPath, "Path to configuration file.")
-	rootCmd.Flags().StringVarP(&RepoType, "type", "t", "", "Filter by repository type (archived, forked, private, template)")
+	rootCmd.Flags().StringVarP(&RepoType, "type", "t", "", "Filter by comma-separated repository types, prefix with ! to exclude (archived, forked, internal, private, public, source, template)")
 	rootCmd.Flags().StringVarP(&LanguageFilter, "language", "l", "", "Filter by primary language")
-	rootCmd.Flags().StringVarP(&SortBy, "sort", "s", "", "Sort repositories by (created, forks, issues, language, name, pushed, size, stars, updated)")
+	rootCmd.Flags().StringVarP(&Filter, "filter", "f", "", "Filter expression, e.g. 'lang:go stars:>10 -is:archived updated:<90d'")
+	rootCmd.Flags().StringVarP(&SortBy, "sort", "s", "", "Sort by comma-separated keys, prefix with + or - for direction (created, forks, issues, language, name, pushed, relevance, size, stars, updated)")
 	rootCmd.Flags().StringVarP(&ProjectsDir, "dir", "d", "", "Directory where repositories will be cloned (overrides config)")
 	rootCmd.Flags().BoolVarP(&RefreshCache, "refresh", "r", false, "Force refresh repositories from GitHub, bypassing cache")
+	rootCmd.Flags().BoolVarP(&DryRun, "dry-run", "n", false, "Show what would be cloned and run without making changes")
+	rootCmd.Flags().BoolVar(&ClonedOnly, "cloned", false, "Only show repositories that are already cloned")
+	rootCmd.Flags().BoolVar(&NotClonedOnly, "not-cloned", false, "Only show repositories that are not cloned yet")
+	rootCmd.MarkFlagsMutuallyExclusive("cloned", "not-cloned")
+	rootCmd.Flags().StringVar(&View, "view", "", "Use a saved view from the config")
 
 	PreviewCmd.Flags().StringVar(&previewUser, "user", "", "The user whose repositories to search for preview")
+	PreviewCmd.Flags().StringVar(&View, "view", "", "The saved view whose repositories to search for preview")
+	PreviewCmd.Flags().BoolVar(&CurrentView, "current-view", false, "Use the view currently shown in the picker")
 	rootCmd.AddCommand(PreviewCmd)
 
 	ListCmd.Flags().StringVar(&listUser, "user", "", "The user whose repositories to list")
 	ListCmd.Flags().StringVarP(&configPath, "config", "c", DefaultConfigPath, "Path to configuration file.")
-	ListCmd.Flags().StringVarP(&RepoType, "type", "t", "", "Filter by repository type")
+	ListCmd.Flags().StringVarP(&RepoType, "type", "t", "", "Filter by comma-separated repository types")
 	ListCmd.Flags().StringVarP(&LanguageFilter, "language", "l", "", "Filter by primary language")
+	ListCmd.Flags().StringVarP(&Filter, "filter", "f", "", "Filter expression")
 	ListCmd.Flags().StringVarP(&SortBy, "sort", "s", "", "Sort repositories by")
+	ListCmd.Flags().BoolVar(&ClonedOnly, "cloned", false, "Only list repositories that are already cloned")
+	ListCmd.Flags().BoolVar(&NotClonedOnly, "not-cloned", false, "Only list repositories that are not cloned yet")
+	ListCmd.MarkFlagsMutuallyExclusive("cloned", "not-cloned")
+	ListCmd.Flags().StringVar(&View, "view", "", "Use a saved view from the config")
+	ListCmd.Flags().BoolVar(&CurrentView, "current-view", false, "Use the view currently shown in the picker")
 	rootCmd.AddCommand(ListCmd)
 }
 
-func runMain() error {
+func runMain(ctx context.Context) error {
 	sortedRepos, err := processRepositories(User)
 	if err != nil {
 		return err
@@ -173,9 +216,13 @@ func runMain() error {
 		return nil
 	}
 
-	repoNames := extractRepoNames(sortedRepos)
+	if len(config.Views) > 0 {
+		if err := SaveCurrentView(View); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to save current view: %v\n", err)
+		}
+	}
 
-	selectedNames, err := runFzfSelection(repoNames, User)
+	key, selectedNames, err := runPicker(sortedRepos, User)
 	if err != nil {
 		if err.Error() == "selection cancelled" {
 			fmt.Println("Selection cancelled.")
@@ -184,34 +231,55 @@ func runMain() error {
 		return err
 	}
 
+	if len(config.Views) > 0 {
+		syncPickerView()
+	}
+
 	finalRepos, err := processRepositories(User)
 	if err != nil {
 		finalRepos = sortedRepos
 	}
 
-	return handleRepoSelection(selectedNames, finalRepos)
+	if action, ok := pickerActions()[key]; ok {
+		return RunPickerAction(action, SelectPickedRepos(finalRepos, selectedNames))
+	}
+	return handleRepoSelection(ctx, selectedNames, finalRepos)
 }
 
-func handleRepoSelection(selectedNames []string, sortedRepos []Repo) error {
+func handleRepoSelection(parent context.Context, selectedNames []string, sortedRepos []Repo) error {
 	if len(selectedNames) == 0 {
 		fmt.Println("No repositories selected.")
 		return nil
 	}
 
-	repoMap := BuildRepoMap(sortedRepos)
-	selectedRepos := SelectReposByNames(repoMap, selectedNames)
+	selectedRepos := SelectPickedRepos(sortedRepos, selectedNames)
 
-	if len(selectedRepos) > 0 {
-		err := CloneRepos(selectedRepos)
-		if err != nil {
-			return fmt.Errorf("error during cloning: %w", err)
-		}
-		err = HandlePostClone(selectedRepos)
-		if err != nil {
+	if len(selectedRepos) == 0 {
+		fmt.Println("No repositories selected.")
+		return nil
+	}
+
+	ctx, cancel := NewCloneContext(parent, len(selectedRepos))
+	defer cancel()
+
+	results := CloneReposWithResults(ctx, selectedRepos)
+	PrintResultSummary(os.Stdout, results)
+
+	if parent.Err() != nil {
+		PrintInterruptedSummary(os.Stdout, results)
+		return &ExitError{Code: ExitCodeInterrupted, Err: fmt.Errorf("cloning interrupted: %w", parent.Err())}
+	}
+
+	RunBootstrap(ctx, results.WithStatus(StatusCloned))
+
+	if succeeded := results.Succeeded(); len(succeeded) > 0 {
+		if err := HandlePostClone(succeeded); err != nil {
 			return fmt.Errorf("error during post-clone handling: %w", err)
 		}
-	} else {
-		fmt.Println("No repositories selected.")
+	}
+
+	if code := results.ExitCode(); code != 0 {
+		return &ExitError{Code: code, Err: fmt.Errorf("error during cloning: %w", results.Err())}
 	}
 
 	return nil
@@ -220,13 +288,16 @@ func handleRepoSelection(selectedNames []string, sortedRepos []Repo) error {
 func buildPreviewCommand(user string) string {
 	cmdInvocation := GetCommandInvocation()
 	var parts []string
-	parts = append(parts, cmdInvocation, "preview", "{}")
+	parts = append(parts, cmdInvocation, "preview", "{1}")
 	if configPath != "" && configPath != DefaultConfigPath {
 		parts = append(parts, "--config", configPath)
 	}
 	if user != "" {
 		parts = append(parts, "--user", user)
 	}
+	if len(config.Views) > 0 {
+		parts = append(parts, "--current-view")
+	}
 	return strings.Join(parts, " ")
 }
 
@@ -240,30 +311,59 @@ func BuildReloadCommand(user string) string {
 	if user != "" {
 		parts = append(parts, "--user", user)
 	}
-	if RepoType != "" {
-		parts = append(parts, "--type", RepoType)
-	}
-	if LanguageFilter != "" {
-		parts = append(parts, "--language", LanguageFilter)
+	if len(config.Views) > 0 {
+		parts = append(parts, "--current-view")
 	}
-	if SortBy != "" {
-		parts = append(parts, "--sort", SortBy)
+	parts = append(parts, reloadFilterArgs()...)
+	switch cloneStateFilter() {
+	case CloneStateCloned:
+		parts = append(parts, "--cloned")
+	case CloneStateNotCloned:
+		parts = append(parts, "--not-cloned")
 	}
 	return strings.Join(parts, " ")
 }
 
-func runFzfSelection(repoNames []string, user string) ([]string, error) {
-	previewCmd := buildPreviewCommand(user)
-	reloadCmd := BuildReloadCommand(user)
-	fzfArgs := []string{
-		"--multi",
-		"--preview", previewCmd,
-		"--bind", "ctrl-r:reload(" + reloadCmd + ")",
-		"--header", "Press Ctrl+r to refresh repositories",
+// reloadFilterArgs returns the type, language, filter and sort flags for the reload command; with views the
+// list follows the picker's current view, so only flags given explicitly are passed on
+func reloadFilterArgs() []string {
+	views := len(config.Views) > 0
+	var args []string
+	for _, setting := range []struct{ flag, value string }{
+		{"type", RepoType},
+		{"language", LanguageFilter},
+		{"filter", Filter},
+		{"sort", SortBy},
+	} {
+		if setting.value != "" && (!views || explicitFlags[setting.flag]) {
+			args = append(args, "--"+setting.flag, shellQuote(setting.value))
+		}
+	}
+	return args
+}
+
+// runPicker lets the user choose repositories with fzf or the built-in picker, depending on ui.picker, and
+// returns the action key pressed, empty for Enter, with the chosen keys
+func runPicker(repos []Repo, user string) (string, []string, error) {
+	if usesBuiltinPicker() {
+		return runBuiltinPicker(repos, user)
+	}
+	return runFzfSelection(FormatPickerLines(repos), user)
+}
+
+// buildCycleViewCommand returns the command fzf runs to switch the picker to the next view
+func buildCycleViewCommand() string {
+	parts := []string{GetCommandInvocation(), "views", "--cycle"}
+	if configPath != "" && configPath != DefaultConfigPath {
+		parts = append(parts, "--config", configPath)
 	}
+	return strings.Join(parts, " ")
+}
 
-	fzfCmd := exec.Command("fzf", fzfArgs...)
-	fzfCmd.Stdin = strings.NewReader(strings.Join(repoNames, "\n"))
+func runFzfSelection(lines []string, user string) (string, []string, error) {
+	expect := pickerActionKeys()
+	fzfCmd := exec.Command("fzf", BuildFzfArgs(user, expect)...)
+	fzfCmd.Stdin = strings.NewReader(strings.Join(lines, "\n"))
 	var out bytes.Buffer
 	fzfCmd.Stdout = &out
 	fzfCmd.Stderr = os.Stderr
@@ -273,39 +373,76 @@ func runFzfSelection(repoNames []string, user string) ([]string, error) {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			exitCode := exitError.ExitCode()
 			if exitCode == 130 || exitCode == 1 {
-				return nil, fmt.Errorf("selection cancelled")
+				return "", nil, fmt.Errorf("selection cancelled")
 			}
 		}
-		return nil, fmt.Errorf("error running fzf: %w", err)
+		return "", nil, fmt.Errorf("error running fzf: %w", err)
 	}
 
-	selectedNames := strings.Split(strings.TrimSpace(out.String()), "\n")
-	return selectedNames, nil
+	key, selectedNames := ParsePickerOutput(out.String(), len(expect) > 0)
+	return key, selectedNames, nil
+}
+
+// ParsePickerOutput returns the key fzf reports on its first line when --expect is used, empty for Enter, and
+// the keys of the chosen entries
+func ParsePickerOutput(output string, expect bool) (string, []string) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	key := ""
+	if expect {
+		key, lines = strings.TrimSpace(lines[0]), lines[1:]
+	}
+
+	var selectedNames []string
+	for _, line := range lines {
+		if name := ParsePickerLine(line); name != "" {
+			selectedNames = append(selectedNames, name)
+		}
+	}
+	return key, selectedNames
 }
 
 func processRepositories(user string) ([]Repo, error) {
-	repos, err := GetRepos(user)
+	repos, err := fetchRepos(user)
 	if err != nil {
 		return nil, err
 	}
 
-	filteredRepos := FilterRepositories(repos, RepoType, LanguageFilter)
-	sortedRepos := SortRepositories(filteredRepos, SortBy)
+	repoType := viewSetting("type", RepoType)
+	if _, err := ParseRepoTypes(repoType); err != nil {
+		return nil, fmt.Errorf("invalid --type: %w", err)
+	}
+	filteredRepos := FilterRepositories(repos, repoType, viewSetting("language", LanguageFilter), cloneStateFilter())
+	if filter := viewSetting("filter", Filter); filter != "" {
+		match, err := ParseFilter(filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %w", err)
+		}
+		filteredRepos = match.Filter(filteredRepos)
+	}
+	sortBy := viewSetting("sort", SortBy)
+	if _, err := ParseSortKeys(sortBy); err != nil {
+		return nil, fmt.Errorf("invalid --sort: %w", err)
+	}
+	sortedRepos := SortRepositories(filteredRepos, sortBy)
 
 	return sortedRepos, nil
 }
 
-func extractRepoNames(repos []Repo) []string {
-	var repoNames []string
-	for _, repo := range repos {
-		repoNames = append(repoNames, repo.Name)
+// cloneStateFilter returns the clone state selected by --cloned or --not-cloned
+func cloneStateFilter() string {
+	switch {
+	case ClonedOnly:
+		return CloneStateCloned
+	case NotClonedOnly:
+		return CloneStateNotCloned
+	default:
+		return ""
 	}
-	return repoNames
 }
 
 func FindRepoByName(repos []Repo, name string) *Repo {
 	for i := range repos {
-		if repos[i].Name == name {
+		if repos[i].Name == name || repos[i].FullName() == name {
 			return &repos[i]
 		}
 	}
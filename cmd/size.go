@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	bytesPerKB = 1024
+	bytesPerMB = 1024 * bytesPerKB
+	bytesPerGB = 1024 * bytesPerMB
+)
+
+// sizeUnits maps the unit letters ParseSize accepts to their size in bytes
+var sizeUnits = map[string]int64{"K": bytesPerKB, "M": bytesPerMB, "G": bytesPerGB}
+
+// ParseSize parses sizes like "500MB", "2G" or "1024" into bytes
+func ParseSize(size string) (int64, error) {
+	size = strings.ToUpper(strings.TrimSpace(size))
+	if size == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	multiplier := int64(1)
+	number := strings.TrimSuffix(size, "B")
+	for unit, unitBytes := range sizeUnits {
+		if trimmed, ok := strings.CutSuffix(number, unit); ok {
+			number, multiplier = trimmed, unitBytes
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size: %s (supported units: B, KB, MB, GB)", size)
+	}
+
+	return int64(value * float64(multiplier)), nil
+}
+
+// FormatSize renders a byte count with the largest fitting unit, such as "1.5 GB"
+func FormatSize(bytes int64) string {
+	switch {
+	case bytes >= bytesPerGB:
+		return fmt.Sprintf("%.1f GB", float64(bytes)/bytesPerGB)
+	case bytes >= bytesPerMB:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/bytesPerMB)
+	case bytes >= bytesPerKB:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/bytesPerKB)
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+const maxCloneSearchDepth = 5
+
+var (
+	statusFormat   string
+	statusDirty    bool
+	statusUnpushed bool
+)
+
+var StatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show branch, changes and push state of every local clone",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runStatus(cmd.Context(), os.Stdout); err != nil {
+			fmt.Println(err)
+			os.Exit(ExitCodeFor(err))
+		}
+	},
+}
+
+func init() {
+	StatusCmd.Flags().StringVarP(&statusFormat, "format", "f", "table", "Output format (table, json)")
+	StatusCmd.Flags().BoolVar(&statusDirty, "dirty", false, "Only show clones with uncommitted changes or stashes")
+	StatusCmd.Flags().BoolVar(&statusUnpushed, "unpushed", false, "Only show clones with commits that are not pushed")
+	rootCmd.AddCommand(StatusCmd)
+}
+
+// LocalRepoStatus describes the working tree state of a local clone
+type LocalRepoStatus struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Branch     string `json:"branch"`
+	Upstream   string `json:"upstream"`
+	Dirty      int    `json:"dirty"`
+	Stashes    int    `json:"stashes"`
+	Ahead      int    `json:"ahead"`
+	Behind     int    `json:"behind"`
+	LastCommit string `json:"last_commit"`
+	Error      string `json:"error,omitempty"`
+}
+
+// IsDirty reports whether the clone has uncommitted changes
+func (s LocalRepoStatus) IsDirty() bool {
+	return s.Dirty > 0
+}
+
+// IsUnpushed reports whether the current branch has commits that are not on its upstream
+func (s LocalRepoStatus) IsUnpushed() bool {
+	return s.Ahead > 0 || (s.Upstream == "" && s.Branch != "" && s.Branch != "HEAD")
+}
+
+// runStatus scans the projects directories and prints the status of every clone
+func runStatus(ctx context.Context, w io.Writer) error {
+	if statusFormat != "table" && statusFormat != "json" {
+		return fmt.Errorf("unsupported format %q (supported: table, json)", statusFormat)
+	}
+
+	var paths []string
+	for _, root := range statusRoots() {
+		found, err := FindLocalClones(root)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, found...)
+	}
+
+	statuses := FilterLocalStatuses(CollectLocalStatuses(ctx, paths), statusDirty, statusUnpushed)
+
+	if statusFormat == "json" {
+		data, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal status: %w", err)
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	if len(statuses) == 0 {
+		fmt.Fprintln(w, "No matching local clones found.")
+		return nil
+	}
+	PrintStatusTable(w, statuses)
+	return nil
+}
+
+// statusRoots returns the expanded projects directory and any rule directories, without duplicates
+func statusRoots() []string {
+	candidates := []string{config.Repos.ProjectsDir}
+	for _, rule := range config.Integrations.Git.Rules {
+		if rule.ProjectsDir != "" {
+			candidates = append(candidates, rule.ProjectsDir)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var roots []string
+	for _, candidate := range candidates {
+		root, err := expandPath(candidate)
+		if err != nil {
+			continue
+		}
+		root = filepath.Clean(root)
+		if seen[root] {
+			continue
+		}
+		seen[root] = true
+		roots = append(roots, root)
+	}
+	return roots
+}
+
+// FindLocalClones walks root and returns every git working tree below it, without descending into clones
+func FindLocalClones(root string) ([]string, error) {
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var clones []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if isGitRepo(path) {
+			clones = append(clones, path)
+			return filepath.SkipDir
+		}
+
+		rel, _ := filepath.Rel(root, path)
+		if rel != "." && (strings.HasPrefix(d.Name(), ".") || strings.Count(rel, string(filepath.Separator)) >= maxCloneSearchDepth-1) {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+
+	sort.Strings(clones)
+	return clones, nil
+}
+
+// CollectLocalStatuses inspects clones concurrently, keeping the order of paths
+func CollectLocalStatuses(ctx context.Context, paths []string) []LocalRepoStatus {
+	statuses := make([]LocalRepoStatus, len(paths))
+	sem := make(chan struct{}, getMaxConcurrentClones())
+	var wg sync.WaitGroup
+
+	for i, path := range paths {
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			statuses[i] = GetLocalRepoStatus(ctx, path)
+		}(i, path)
+	}
+
+	wg.Wait()
+	return statuses
+}
+
+// GetLocalRepoStatus reads the branch, changes, stashes and upstream divergence of a clone
+func GetLocalRepoStatus(ctx context.Context, path string) LocalRepoStatus {
+	status := LocalRepoStatus{Name: displayClonePath(path), Path: path}
+
+	branch, err := getCurrentBranch(ctx, path)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Branch = branch
+
+	if out, err := runGit(ctx, path, "status", "--porcelain"); err == nil {
+		status.Dirty = countLines(out)
+	}
+	if out, err := runGit(ctx, path, "stash", "list"); err == nil {
+		status.Stashes = countLines(out)
+	}
+	if out, err := runGit(ctx, path, "log", "-1", "--format=%h %s (%cr)"); err == nil {
+		status.LastCommit = out
+	}
+
+	upstream, err := runGit(ctx, path, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	if err != nil {
+		return status
+	}
+	status.Upstream = upstream
+
+	if out, err := runGit(ctx, path, "rev-list", "--left-right", "--count", "HEAD...@{upstream}"); err == nil {
+		if counts := strings.Fields(out); len(counts) == 2 {
+			status.Ahead, _ = strconv.Atoi(counts[0])
+			status.Behind, _ = strconv.Atoi(counts[1])
+		}
+	}
+
+	return status
+}
+
+// FilterLocalStatuses keeps clones that are dirty or have stashes, or are unpushed, when the matching filter is set
+func FilterLocalStatuses(statuses []LocalRepoStatus, dirty, unpushed bool) []LocalRepoStatus {
+	if !dirty && !unpushed {
+		return statuses
+	}
+
+	filtered := make([]LocalRepoStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if (dirty && (status.IsDirty() || status.Stashes > 0)) || (unpushed && status.IsUnpushed()) {
+			filtered = append(filtered, status)
+		}
+	}
+	return filtered
+}
+
+// PrintStatusTable writes clone statuses as an aligned table
+func PrintStatusTable(w io.Writer, statuses []LocalRepoStatus) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\tREPO\tBRANCH\tCHANGES\tSTASHES\tAHEAD/BEHIND\tLAST COMMIT")
+	for _, status := range statuses {
+		if status.Error != "" {
+			fmt.Fprintf(tw, "%s\t%s\t\t\t\t\t%s\n", GetIcon("error"), status.Name, status.Error)
+			continue
+		}
+
+		divergence := fmt.Sprintf("%d/%d", status.Ahead, status.Behind)
+		if status.Upstream == "" {
+			divergence = "no upstream"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s %s\t%d\t%d\t%s\t%s\n",
+			localStatusIcon(status), status.Name, GetIcon("branch"), status.Branch,
+			status.Dirty, status.Stashes, divergence, status.LastCommit)
+	}
+	_ = tw.Flush()
+}
+
+// localStatusIcon picks the icon summarising a clone's state
+func localStatusIcon(status LocalRepoStatus) string {
+	switch {
+	case status.IsDirty():
+		return GetIcon("error")
+	case status.IsUnpushed() || status.Stashes > 0:
+		return GetIcon("info")
+	default:
+		return GetIcon("success")
+	}
+}
+
+// displayClonePath shortens a clone path relative to the projects directory when possible
+func displayClonePath(path string) string {
+	for _, root := range statusRoots() {
+		if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return path
+}
+
+func countLines(out string) int {
+	if out == "" {
+		return 0
+	}
+	return len(strings.Split(out, "\n"))
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var syncUser string
+
+var SyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Fetch and fast-forward every local clone of your repositories",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runSync(cmd.Context(), syncUser); err != nil {
+			fmt.Println(err)
+			os.Exit(ExitCodeFor(err))
+		}
+	},
+}
+
+func init() {
+	SyncCmd.Flags().StringVarP(&syncUser, "user", "u", "", "The user whose repositories to sync")
+	rootCmd.AddCommand(SyncCmd)
+}
+
+// runSync syncs the local clones of every known repository of a user
+func runSync(parent context.Context, user string) error {
+	repos, err := GetRepos(user)
+	if err != nil {
+		return err
+	}
+
+	clones := FindClonedRepos(repos)
+	if len(clones) == 0 {
+		fmt.Println("No local clones found.")
+		return nil
+	}
+
+	ctx, cancel := NewCloneContext(parent, len(clones))
+	defer cancel()
+
+	results := SyncRepos(ctx, clones)
+	PrintResultSummary(os.Stdout, results)
+
+	if parent.Err() != nil {
+		PrintInterruptedSummary(os.Stdout, results)
+		return &ExitError{Code: ExitCodeInterrupted, Err: fmt.Errorf("sync interrupted: %w", parent.Err())}
+	}
+
+	if code := results.ExitCode(); code != 0 {
+		return &ExitError{Code: code, Err: fmt.Errorf("error during sync: %w", results.Err())}
+	}
+	return nil
+}
+
+// FindClonedRepos returns the repositories that have a git clone at their resolved target path
+func FindClonedRepos(repos []Repo) []Repo {
+	var cloned []Repo
+	for _, repo := range repos {
+		if path, err := GetRepoPath(repo); err == nil && isGitRepo(path) {
+			cloned = append(cloned, repo)
+		}
+	}
+	return cloned
+}
+
+// SyncRepos fetches and fast-forwards local clones concurrently, skipping dirty working trees
+func SyncRepos(ctx context.Context, repos []Repo) RepoResults {
+	if len(repos) == 0 {
+		return nil
+	}
+
+	maxConcurrent := getMaxConcurrentClones()
+	fmt.Printf("Syncing %d local clones with up to %d concurrent operations...\n", len(repos), maxConcurrent)
+
+	return runConcurrently(ctx, repos, maxConcurrent, func(ctx context.Context, index int, repo Repo) RepoResult {
+		return syncSingleRepo(ctx, index, repo, len(repos))
+	})
+}
+
+// syncSingleRepo fetches and fast-forwards a single local clone
+func syncSingleRepo(ctx context.Context, index int, repo Repo, totalRepos int) RepoResult {
+	prefix := fmt.Sprintf("[%d/%d]", index+1, totalRepos)
+
+	path, err := GetRepoPath(repo)
+	if err != nil {
+		return RepoResult{Repo: repo, Status: StatusFailed, Err: fmt.Errorf("failed to get target directory: %w", err)}
+	}
+
+	origin, err := getOriginURL(ctx, path)
+	if err != nil || !RemoteMatchesRepo(origin, repo) {
+		reason := fmt.Errorf("%s is not a clone of %s", path, repo.HTMLURL)
+		printCloneStatus(fmt.Sprintf("%s %s %v\n", prefix, GetIcon("error"), reason))
+		return RepoResult{Repo: repo, Path: path, Status: StatusMismatch, Err: reason}
+	}
+
+	dirty, err := isWorkingTreeDirty(ctx, path)
+	if err != nil {
+		return syncFailure(ctx, repo, path, err)
+	}
+	if dirty {
+		printCloneStatus(fmt.Sprintf("%s %s %s has uncommitted changes, skipping\n", prefix, GetIcon("info"), repo.Name))
+		return RepoResult{Repo: repo, Path: path, Status: StatusDirty}
+	}
+
+	changed, err := updateExistingClone(ctx, path, true)
+	if err != nil {
+		return syncFailure(ctx, repo, path, err)
+	}
+
+	if !changed {
+		printCloneStatus(fmt.Sprintf("%s %s %s is up to date\n", prefix, GetIcon("success"), repo.Name))
+		return RepoResult{Repo: repo, Path: path, Status: StatusUpToDate}
+	}
+
+	printCloneStatus(fmt.Sprintf("%s %s Fast-forwarded %s\n", prefix, GetIcon("success"), repo.Name))
+	return RepoResult{Repo: repo, Path: path, Status: StatusUpdated}
+}
+
+// syncFailure builds the result for a sync that failed or was cancelled
+func syncFailure(ctx context.Context, repo Repo, path string, err error) RepoResult {
+	status := StatusFailed
+	if ctx.Err() != nil {
+		status = StatusCancelled
+	}
+	return RepoResult{Repo: repo, Path: path, Status: status, Err: fmt.Errorf("failed to sync %s: %w", repo.Name, err)}
+}
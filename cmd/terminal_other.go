@@ -0,0 +1,16 @@
+//go:build !windows
+
+package cmd
+
+import "os"
+
+// The controlling terminal the built-in picker falls back to when stdin or stdout is redirected
+const (
+	terminalInput  = "/dev/tty"
+	terminalOutput = "/dev/tty"
+)
+
+// enableVirtualTerminal is not needed outside Windows, terminals already interpret escape sequences
+func enableVirtualTerminal(_ *os.File) func() {
+	return func() {}
+}
@@ -0,0 +1,29 @@
+//go:build windows
+
+package cmd
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// The console devices the built-in picker falls back to when stdin or stdout is redirected
+const (
+	terminalInput  = "CONIN$"
+	terminalOutput = "CONOUT$"
+)
+
+// enableVirtualTerminal makes the console interpret the escape sequences the picker draws with and returns a
+// function that restores the previous mode
+func enableVirtualTerminal(out *os.File) func() {
+	handle := windows.Handle(out.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return func() {}
+	}
+	if err := windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil {
+		return func() {}
+	}
+	return func() { _ = windows.SetConsoleMode(handle, mode) }
+}
@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	TmuxModeSession = "session"
+	TmuxModeWindow  = "window"
+)
+
+var tmuxUser string
+
+var TmuxCmd = &cobra.Command{
+	Use:   "tmux <repo>...",
+	Short: "Open local clones in tmux sessions or windows",
+	Long:  "Open local clones matching the given names or owner/name globs in tmux, using the integrations.tmux layout.",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runTmux(tmuxUser, args); err != nil {
+			fmt.Println(err)
+			os.Exit(ExitCodeFor(err))
+		}
+	},
+}
+
+func init() {
+	TmuxCmd.Flags().StringVarP(&tmuxUser, "user", "u", "", "The user whose repositories to look up")
+	rootCmd.AddCommand(TmuxCmd)
+}
+
+// runTmux opens the local clones of a user that match any of the patterns
+func runTmux(user string, patterns []string) error {
+	repos, err := GetRepos(user)
+	if err != nil {
+		return err
+	}
+
+	var selected []Repo
+	for _, repo := range FindClonedRepos(repos) {
+		for _, pattern := range patterns {
+			if matchRepoPattern(pattern, repo) {
+				selected = append(selected, repo)
+				break
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("no local clones match %s", strings.Join(patterns, ", "))
+	}
+
+	if !DryRun && !isCommandAvailable("tmux") {
+		return fmt.Errorf("command tmux is not available in PATH")
+	}
+	return OpenInTmux(selected)
+}
+
+// tmuxTarget identifies where a repository was opened
+type tmuxTarget struct {
+	Session string
+	Window  string
+}
+
+// TmuxName returns the tmux session or window name for a repository
+func TmuxName(repo Repo) string {
+	return strings.NewReplacer(".", "_", ":", "_").Replace(repo.FullName())
+}
+
+// OpenInTmux creates or reuses a tmux session, or a window in the shared session, for every repository
+// and switches to the first one
+func OpenInTmux(repos []Repo) error {
+	if len(repos) == 0 {
+		return nil
+	}
+
+	if DryRun {
+		for _, repo := range repos {
+			fmt.Printf("%s Would open %s in %s\n", GetIcon("info"), repo.FullName(), tmuxPlacement(repo))
+		}
+		return nil
+	}
+
+	fmt.Printf("%s Opening selected repos in tmux\n", GetIcon("info"))
+	var first *tmuxTarget
+	for _, repo := range repos {
+		target, err := openTmuxTarget(repo)
+		if err != nil {
+			fmt.Printf("%s Failed to open %s in tmux: %v\n", GetIcon("error"), repo.Name, err)
+			continue
+		}
+		if first == nil {
+			first = &target
+		}
+	}
+
+	if first == nil || !config.Integrations.Tmux.Switch {
+		return nil
+	}
+	return switchTmuxClient(*first)
+}
+
+// openTmuxTarget opens a repository in its own session or as a window of the shared session
+func openTmuxTarget(repo Repo) (tmuxTarget, error) {
+	repoPath, err := GetRepoPath(repo)
+	if err != nil {
+		return tmuxTarget{}, fmt.Errorf("failed to get target directory for %s: %w", repo.Name, err)
+	}
+	values, _, err := hookValues(repo, nil)
+	if err != nil {
+		return tmuxTarget{}, err
+	}
+
+	name := TmuxName(repo)
+	if tmuxMode() == TmuxModeWindow {
+		return openTmuxWindow(tmuxSession(), name, repoPath, values)
+	}
+
+	if tmuxHasSession(name) {
+		return tmuxTarget{Session: name}, nil
+	}
+	for i, window := range tmuxWindows() {
+		create := []string{"new-window", "-d", "-t", "=" + name + ":"}
+		if i == 0 {
+			create = []string{"new-session", "-d", "-s", name}
+		}
+		if window.Name != "" {
+			create = append(create, "-n", window.Name)
+		}
+		if _, err := createTmuxWindow(create, repoPath, window, values); err != nil {
+			return tmuxTarget{}, err
+		}
+	}
+	return tmuxTarget{Session: name}, nil
+}
+
+// openTmuxWindow opens a repository as windows named after it in the shared session, reusing existing ones
+func openTmuxWindow(session, name, repoPath string, values map[string]string) (tmuxTarget, error) {
+	exists := tmuxHasSession(session)
+	if exists {
+		if id := findTmuxWindow(session, name); id != "" {
+			return tmuxTarget{Session: session, Window: id}, nil
+		}
+	}
+
+	var first string
+	for i, window := range tmuxWindows() {
+		windowName := name
+		if i > 0 {
+			windowName = fmt.Sprintf("%s/%s", name, tmuxWindowName(window, i))
+		}
+
+		create := []string{"new-window", "-d", "-t", "=" + session + ":", "-n", windowName}
+		if !exists {
+			create = []string{"new-session", "-d", "-s", session, "-n", windowName}
+			exists = true
+		}
+		id, err := createTmuxWindow(create, repoPath, window, values)
+		if err != nil {
+			return tmuxTarget{}, err
+		}
+		if first == "" {
+			first = id
+		}
+	}
+	return tmuxTarget{Session: session, Window: first}, nil
+}
+
+// createTmuxWindow runs a new-session or new-window command and splits the new window into the configured panes
+func createTmuxWindow(create []string, repoPath string, window TmuxWindow, values map[string]string) (string, error) {
+	args := append(create, "-P", "-F", "#{window_id} #{pane_id}", "-c", repoPath)
+	output, err := tmuxOutput(args...)
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return "", fmt.Errorf("unexpected tmux output %q", output)
+	}
+	windowID, paneID := fields[0], fields[1]
+
+	panes := window.Panes
+	if len(panes) == 0 {
+		panes = []string{""}
+	}
+	for i, command := range panes {
+		if i > 0 {
+			if paneID, err = tmuxOutput("split-window", "-d", "-t", windowID, "-c", repoPath, "-P", "-F", "#{pane_id}"); err != nil {
+				return "", err
+			}
+		}
+		if command = ExpandHookTemplate(command, values); command != "" {
+			if _, err := tmuxOutput("send-keys", "-t", paneID, command, "Enter"); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if window.Layout != "" {
+		if _, err := tmuxOutput("select-layout", "-t", windowID, window.Layout); err != nil {
+			return "", err
+		}
+	}
+	return windowID, nil
+}
+
+// findTmuxWindow returns the id of the window with the given name in a session, or an empty string
+func findTmuxWindow(session, name string) string {
+	output, err := tmuxOutput("list-windows", "-t", "="+session, "-F", "#{window_name}\t#{window_id}")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if windowName, id, ok := strings.Cut(line, "\t"); ok && windowName == name {
+			return id
+		}
+	}
+	return ""
+}
+
+// switchTmuxClient switches the current client to the target, or attaches to it when running outside tmux
+func switchTmuxClient(target tmuxTarget) error {
+	if target.Window != "" {
+		if _, err := tmuxOutput("select-window", "-t", target.Window); err != nil {
+			return err
+		}
+	}
+
+	if os.Getenv("TMUX") != "" {
+		_, err := tmuxOutput("switch-client", "-t", "="+target.Session)
+		return err
+	}
+
+	cmd := ExecCommand("tmux", "attach-session", "-t", "="+target.Session)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to attach to tmux session %s: %w", target.Session, err)
+	}
+	return nil
+}
+
+// tmuxHasSession reports whether a session with exactly this name exists
+func tmuxHasSession(name string) bool {
+	return ExecCommand("tmux", "has-session", "-t", "="+name).Run() == nil
+}
+
+// tmuxOutput runs a tmux command and returns its trimmed output
+func tmuxOutput(args ...string) (string, error) {
+	output, err := ExecCommand("tmux", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("tmux %s failed: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// tmuxPlacement describes where a repository would be opened
+func tmuxPlacement(repo Repo) string {
+	if tmuxMode() == TmuxModeWindow {
+		return fmt.Sprintf("tmux window %s of session %s", TmuxName(repo), tmuxSession())
+	}
+	return fmt.Sprintf("tmux session %s", TmuxName(repo))
+}
+
+func tmuxMode() string {
+	if config.Integrations.Tmux.Mode == "" {
+		return TmuxModeSession
+	}
+	return strings.ToLower(config.Integrations.Tmux.Mode)
+}
+
+func tmuxSession() string {
+	if config.Integrations.Tmux.Session == "" {
+		return "repos"
+	}
+	return config.Integrations.Tmux.Session
+}
+
+// tmuxWindows returns the configured windows, or a single window with a shell
+func tmuxWindows() []TmuxWindow {
+	if len(config.Integrations.Tmux.Windows) == 0 {
+		return []TmuxWindow{{}}
+	}
+	return config.Integrations.Tmux.Windows
+}
+
+func tmuxWindowName(window TmuxWindow, index int) string {
+	if window.Name != "" {
+		return window.Name
+	}
+	return fmt.Sprint(index + 1)
+}
+
+// validateTmux checks the tmux mode and shared session name
+func validateTmux(tmux TmuxConfig) error {
+	switch strings.ToLower(tmux.Mode) {
+	case "", TmuxModeSession, TmuxModeWindow:
+	default:
+		return fmt.Errorf("invalid integrations.tmux.mode: %q (supported: session, window)", tmux.Mode)
+	}
+	if strings.ContainsAny(tmux.Session, ".:") {
+		return fmt.Errorf("invalid integrations.tmux.session: %q must not contain '.' or ':'", tmux.Session)
+	}
+	return nil
+}
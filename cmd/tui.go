@@ -0,0 +1,524 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/term"
+)
+
+const (
+	PickerAuto    = "auto"
+	PickerFzf     = "fzf"
+	PickerBuiltin = "builtin"
+)
+
+const (
+	keyCtrlC     = 3
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlR     = 18
+	keyCtrlU     = 21
+	keyCtrlV     = 22
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+// errSelectionCancelled matches the error fzf selections report when the picker is closed without choosing
+var errSelectionCancelled = errors.New("selection cancelled")
+
+// BuiltinPicker is a fuzzy, multi-select picker with a preview pane for terminals without fzf
+type BuiltinPicker struct {
+	Lines   []string
+	Header  string
+	Width   int
+	Height  int
+	Preview func(key string) string
+	Reload  func() ([]string, error)
+	// CycleView switches to the next view and returns its header, the lines are reloaded afterwards
+	CycleView func() (string, error)
+	// Expect lists keys such as ctrl-o or alt-s that end the selection like Enter, Key reports which was pressed
+	Expect []string
+	Key    string
+
+	query    []rune
+	matches  []int
+	cursor   int
+	offset   int
+	selected map[string]bool
+	picked   []string
+	status   string
+	previews map[string]string
+}
+
+// FuzzyMatch reports whether every character of pattern appears in text in order, ignoring case, and scores
+// consecutive characters and characters at word starts higher
+func FuzzyMatch(pattern, text string) (int, bool) {
+	patternRunes := []rune(strings.ToLower(pattern))
+	if len(patternRunes) == 0 {
+		return 0, true
+	}
+
+	score, matched := 0, 0
+	previousMatch := -2
+	textRunes := []rune(strings.ToLower(text))
+	for i, r := range textRunes {
+		if r != patternRunes[matched] {
+			continue
+		}
+		score++
+		if previousMatch == i-1 {
+			score += 5
+		}
+		if i == 0 || !unicode.IsLetter(textRunes[i-1]) && !unicode.IsDigit(textRunes[i-1]) {
+			score += 3
+		}
+		previousMatch = i
+		matched++
+		if matched == len(patternRunes) {
+			return score, true
+		}
+	}
+	return 0, false
+}
+
+// Run reads keys from in and draws the picker to out until repositories are chosen, returning their keys
+func (p *BuiltinPicker) Run(in io.Reader, out io.Writer) ([]string, error) {
+	p.selected = make(map[string]bool)
+	p.previews = make(map[string]string)
+	p.filter()
+
+	reader := bufio.NewReader(in)
+	for {
+		p.render(out)
+		r, _, err := reader.ReadRune()
+		if err != nil {
+			return nil, errSelectionCancelled
+		}
+
+		done, err := p.handleKey(r, reader)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return p.result()
+		}
+	}
+}
+
+// handleKey applies one key press and reports whether the selection is complete
+func (p *BuiltinPicker) handleKey(r rune, reader *bufio.Reader) (bool, error) {
+	if r > 0 && r < keyEscape && !strings.ContainsRune("\b\t\n\r", r) && p.expects(fmt.Sprintf("ctrl-%c", 'a'+r-1)) {
+		return true, nil
+	}
+
+	switch r {
+	case keyEnter, '\n':
+		return true, nil
+	case keyCtrlC:
+		return false, errSelectionCancelled
+	case keyEscape:
+		if reader.Buffered() == 0 {
+			return false, errSelectionCancelled
+		}
+		return p.handleEscapeSequence(reader), nil
+	case '\t':
+		p.toggle()
+		p.move(1)
+	case keyCtrlN:
+		p.move(1)
+	case keyCtrlP:
+		p.move(-1)
+	case keyCtrlR:
+		p.reload()
+	case keyCtrlV:
+		p.cycleView()
+	default:
+		p.editQuery(r)
+	}
+	return false, nil
+}
+
+// editQuery types, deletes or clears the query and filters the lines again
+func (p *BuiltinPicker) editQuery(r rune) {
+	switch {
+	case r == keyCtrlU:
+		p.query = nil
+	case r == keyBackspace || r == '\b':
+		if len(p.query) == 0 {
+			return
+		}
+		p.query = p.query[:len(p.query)-1]
+	case unicode.IsPrint(r):
+		p.query = append(p.query, r)
+	default:
+		return
+	}
+	p.filter()
+}
+
+// handleEscapeSequence applies the arrow, shift-tab and alt keys terminals send as escape sequences and reports
+// whether an expected alt key ended the selection
+func (p *BuiltinPicker) handleEscapeSequence(reader *bufio.Reader) bool {
+	b, err := reader.ReadByte()
+	if err != nil {
+		return false
+	}
+	if b != '[' && b != 'O' {
+		return p.expects(fmt.Sprintf("alt-%c", b))
+	}
+	if b, err = reader.ReadByte(); err != nil {
+		return false
+	}
+	switch b {
+	case 'A':
+		p.move(-1)
+	case 'B':
+		p.move(1)
+	case 'Z':
+		p.toggle()
+		p.move(-1)
+	}
+	return false
+}
+
+// expects records key as the pressed key when it is one of the expected keys
+func (p *BuiltinPicker) expects(key string) bool {
+	if !slices.Contains(p.Expect, key) {
+		return false
+	}
+	p.Key = key
+	return true
+}
+
+// filter keeps the lines matching every word of the query, best matches first
+func (p *BuiltinPicker) filter() {
+	terms := strings.Fields(string(p.query))
+	scores := make(map[int]int)
+	p.matches = p.matches[:0]
+	for i, line := range p.Lines {
+		_, visible, found := strings.Cut(line, pickerDelimiter)
+		if !found {
+			visible = line
+		}
+		total, ok := 0, true
+		for _, term := range terms {
+			score, matched := FuzzyMatch(term, visible)
+			if !matched {
+				ok = false
+				break
+			}
+			total += score
+		}
+		if ok {
+			scores[i] = total
+			p.matches = append(p.matches, i)
+		}
+	}
+	sort.SliceStable(p.matches, func(a, b int) bool { return scores[p.matches[a]] > scores[p.matches[b]] })
+	p.cursor, p.offset = 0, 0
+}
+
+// move moves the cursor by delta lines within the matches
+func (p *BuiltinPicker) move(delta int) {
+	p.cursor = max(0, min(p.cursor+delta, len(p.matches)-1))
+}
+
+// toggle selects or deselects the line under the cursor
+func (p *BuiltinPicker) toggle() {
+	key, ok := p.current()
+	if !ok {
+		return
+	}
+	if p.selected[key] {
+		delete(p.selected, key)
+		p.picked = removeString(p.picked, key)
+		return
+	}
+	p.selected[key] = true
+	p.picked = append(p.picked, key)
+}
+
+// current returns the key of the line under the cursor
+func (p *BuiltinPicker) current() (string, bool) {
+	if p.cursor >= len(p.matches) {
+		return "", false
+	}
+	return ParsePickerLine(p.Lines[p.matches[p.cursor]]), true
+}
+
+// result returns the selected keys in the order they were selected, or the line under the cursor
+func (p *BuiltinPicker) result() ([]string, error) {
+	if len(p.picked) > 0 {
+		return p.picked, nil
+	}
+	if key, ok := p.current(); ok {
+		return []string{key}, nil
+	}
+	return nil, errSelectionCancelled
+}
+
+// reload replaces the lines with fresh ones, keeping the query and selection
+func (p *BuiltinPicker) reload() {
+	if p.Reload == nil {
+		return
+	}
+	lines, err := p.Reload()
+	if err != nil {
+		p.status = err.Error()
+		return
+	}
+	p.Lines, p.status = lines, ""
+	p.previews = make(map[string]string)
+	p.filter()
+}
+
+// cycleView switches to the next view and reloads its lines
+func (p *BuiltinPicker) cycleView() {
+	if p.CycleView == nil {
+		return
+	}
+	header, err := p.CycleView()
+	if err != nil {
+		p.status = err.Error()
+		return
+	}
+	p.Header = header
+	p.reload()
+}
+
+// render draws the prompt, header, matching lines and the preview of the line under the cursor
+func (p *BuiltinPicker) render(out io.Writer) {
+	listHeight := max(p.Height-2, 1)
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+listHeight {
+		p.offset = p.cursor - listHeight + 1
+	}
+
+	listWidth, previewWidth := p.Width, 0
+	if p.Width >= 80 && p.Preview != nil {
+		listWidth = p.Width / 2
+		previewWidth = p.Width - listWidth - 3
+	}
+	previewLines := p.previewLines()
+
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+	fmt.Fprintf(&b, "> %s\x1b[K\r\n", string(p.query))
+	header := fmt.Sprintf("%d/%d  %s", len(p.matches), len(p.Lines), p.Header)
+	if p.status != "" {
+		header = p.status
+	}
+	fmt.Fprintf(&b, "\x1b[2m%s\x1b[0m\x1b[K", truncateRunes(header, p.Width))
+
+	for row := 0; row < listHeight; row++ {
+		b.WriteString("\r\n")
+		item := p.offset + row
+		text := ""
+		if item < len(p.matches) {
+			text = p.formatItem(item, listWidth)
+		}
+		if previewWidth > 0 {
+			text += strings.Repeat(" ", max(listWidth-len([]rune(stripANSI(text))), 0)) + " │ "
+			if row < len(previewLines) {
+				text += truncateRunes(previewLines[row], previewWidth)
+			}
+		}
+		b.WriteString(text + "\x1b[K")
+	}
+	fmt.Fprintf(&b, "\x1b[1;%dH", len(p.query)+3)
+	_, _ = io.WriteString(out, b.String())
+}
+
+// formatItem renders one matching line with its cursor and selection markers
+func (p *BuiltinPicker) formatItem(item, width int) string {
+	line := p.Lines[p.matches[item]]
+	_, visible, found := strings.Cut(line, pickerDelimiter)
+	if !found {
+		visible = line
+	}
+
+	marker := "  "
+	if p.selected[ParsePickerLine(line)] {
+		marker = "+ "
+	}
+	text := truncateRunes(marker+visible, width)
+	if item == p.cursor {
+		return "\x1b[7m" + text + "\x1b[0m"
+	}
+	return text
+}
+
+// previewLines returns the cached preview of the line under the cursor
+func (p *BuiltinPicker) previewLines() []string {
+	key, ok := p.current()
+	if !ok || p.Preview == nil {
+		return nil
+	}
+	preview, cached := p.previews[key]
+	if !cached {
+		preview = p.Preview(key)
+		p.previews[key] = preview
+	}
+	return strings.Split(strings.TrimRight(preview, "\n"), "\n")
+}
+
+// truncateRunes shortens s to at most n runes
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:max(n, 0)])
+}
+
+// stripANSI removes the escape sequences the picker adds around highlighted lines
+func stripANSI(s string) string {
+	return strings.NewReplacer("\x1b[7m", "", "\x1b[0m", "").Replace(s)
+}
+
+// removeString returns values without the first occurrence of s
+func removeString(values []string, s string) []string {
+	for i, value := range values {
+		if value == s {
+			return append(values[:i], values[i+1:]...)
+		}
+	}
+	return values
+}
+
+// pickerMode returns the configured picker, auto when unset
+func pickerMode() string {
+	if config.UI.Picker == "" {
+		return PickerAuto
+	}
+	return strings.ToLower(config.UI.Picker)
+}
+
+// usesBuiltinPicker reports whether the built-in picker replaces fzf, which auto does when fzf is not installed
+func usesBuiltinPicker() bool {
+	switch pickerMode() {
+	case PickerBuiltin:
+		return true
+	case PickerAuto:
+		_, err := exec.LookPath("fzf")
+		return err != nil
+	default:
+		return false
+	}
+}
+
+// validatePicker checks the ui.picker setting
+func validatePicker(picker string) error {
+	switch strings.ToLower(picker) {
+	case "", PickerAuto, PickerFzf, PickerBuiltin:
+		return nil
+	default:
+		return fmt.Errorf("invalid ui.picker: %q (supported: auto, fzf, builtin)", picker)
+	}
+}
+
+// runBuiltinPicker runs the built-in picker on the terminal and returns the action key pressed, if any, with
+// the chosen keys
+func runBuiltinPicker(repos []Repo, user string) (string, []string, error) {
+	in, out, closeTerminal, err := openTerminal()
+	if err != nil {
+		return "", nil, fmt.Errorf("built-in picker needs a terminal: %w", err)
+	}
+	defer closeTerminal()
+
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to switch terminal to raw mode: %w", err)
+	}
+	defer func() { _ = term.Restore(int(in.Fd()), state) }()
+	defer enableVirtualTerminal(out)()
+
+	height, width := terminalSize(out)
+	fmt.Fprint(out, "\x1b[?1049h")
+	defer fmt.Fprint(out, "\x1b[?1049l")
+
+	picker := newBuiltinPicker(repos, user)
+	picker.Width, picker.Height = width, height
+	keys, err := picker.Run(in, out)
+	return picker.Key, keys, err
+}
+
+// openTerminal returns the terminal to read keys from and draw on: stdin and stdout when both are terminals,
+// otherwise the console of the process
+func openTerminal() (*os.File, *os.File, func(), error) {
+	if term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd())) {
+		return os.Stdin, os.Stdout, func() {}, nil
+	}
+
+	in, err := os.OpenFile(terminalInput, os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	out, err := os.OpenFile(terminalOutput, os.O_RDWR, 0)
+	if err != nil {
+		_ = in.Close()
+		return nil, nil, nil, err
+	}
+	return in, out, func() {
+		_ = in.Close()
+		_ = out.Close()
+	}, nil
+}
+
+// newBuiltinPicker wires the built-in picker to repository previews, reloads and views
+func newBuiltinPicker(repos []Repo, user string) *BuiltinPicker {
+	picker := &BuiltinPicker{
+		Lines:  FormatPickerLines(repos),
+		Expect: pickerActionKeys(),
+		Header: "Tab to select, Ctrl+r to refresh, Esc to cancel",
+		Reload: func() ([]string, error) {
+			oldRefresh := RefreshCache
+			RefreshCache = true
+			defer func() { RefreshCache = oldRefresh }()
+
+			reloaded, err := processRepositories(user)
+			if err != nil {
+				return nil, err
+			}
+			repos = reloaded
+			return FormatPickerLines(repos), nil
+		},
+		Preview: func(key string) string {
+			if repo := FindRepoByName(repos, key); repo != nil {
+				return BuildRepoPreview(*repo)
+			}
+			return ""
+		},
+	}
+	if len(config.Views) > 0 {
+		picker.Header = ViewHeader(View)
+		picker.CycleView = func() (string, error) {
+			name, err := CycleView()
+			if err != nil {
+				return "", err
+			}
+			syncPickerView()
+			return ViewHeader(name), nil
+		}
+	}
+	return picker
+}
+
+// terminalSize returns the rows and columns of the terminal, 24x80 when unknown
+func terminalSize(out *os.File) (int, int) {
+	if width, height, err := term.GetSize(int(out.Fd())); err == nil && width > 0 && height > 0 {
+		return height, width
+	}
+	return 24, 80
+}
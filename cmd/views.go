@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	ViewSourceOwned   = "owned"
+	ViewSourceStarred = "starred"
+	ViewSourceSearch  = "search"
+)
+
+// viewStateFile records the view shown in the picker so reloads and previews follow view cycling
+const viewStateFile = "current_view"
+
+var viewSources = []string{ViewSourceOwned, ViewSourceSearch, ViewSourceStarred}
+
+var (
+	View        string
+	CurrentView bool
+	cycleView   bool
+)
+
+var (
+	activeView    *ViewConfig
+	explicitFlags = map[string]bool{}
+)
+
+var ViewsCmd = &cobra.Command{
+	Use:   "views",
+	Short: "List the saved views from the configuration",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !cycleView {
+			PrintViews(os.Stdout)
+			return
+		}
+		name, err := CycleView()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(ExitCodeFor(err))
+		}
+		fmt.Print(ViewHeader(name))
+	},
+}
+
+func init() {
+	ViewsCmd.Flags().StringVarP(&configPath, "config", "c", DefaultConfigPath, "Path to configuration file.")
+	ViewsCmd.Flags().BoolVar(&cycleView, "cycle", false, "Switch the picker to the next view and print its header")
+	_ = ViewsCmd.Flags().MarkHidden("cycle")
+	rootCmd.AddCommand(ViewsCmd)
+}
+
+// ViewNames returns the configured view names in sorted order
+func ViewNames() []string {
+	names := make([]string, 0, len(config.Views))
+	for name := range config.Views {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NextView returns the view after current in name order, wrapping around to the first
+func NextView(current string) string {
+	names := ViewNames()
+	if len(names) == 0 {
+		return ""
+	}
+	for i, name := range names {
+		if name == current {
+			return names[(i+1)%len(names)]
+		}
+	}
+	return names[0]
+}
+
+// ApplyView selects the named view, whose settings apply to every filter and sort flag changed reports as unset
+func ApplyView(name string, changed func(flag string) bool) error {
+	for _, flag := range []string{"type", "language", "filter", "sort"} {
+		explicitFlags[flag] = changed(flag)
+	}
+
+	activeView = nil
+	if name == "" {
+		return nil
+	}
+	view, ok := config.Views[name]
+	if !ok {
+		return fmt.Errorf("unknown view %q (available: %s)", name, strings.Join(ViewNames(), ", "))
+	}
+	activeView = &view
+	return nil
+}
+
+// resolveView applies --view, or the view currently shown in the picker with --current-view
+func resolveView(changed func(flag string) bool) error {
+	name := View
+	if CurrentView {
+		if current, err := LoadCurrentView(); err == nil {
+			name = current
+		}
+	}
+	return ApplyView(name, changed)
+}
+
+// viewSetting returns the active view's value for a filter or sort flag unless the flag was given explicitly
+func viewSetting(flag, value string) string {
+	if activeView == nil || explicitFlags[flag] {
+		return value
+	}
+
+	var viewValue string
+	switch flag {
+	case "type":
+		viewValue = activeView.Type
+	case "language":
+		viewValue = activeView.Language
+	case "filter":
+		viewValue = activeView.Filter
+	case "sort":
+		viewValue = activeView.Sort
+	}
+	if viewValue == "" {
+		return value
+	}
+	return viewValue
+}
+
+// fetchRepos fetches the repositories of the active view, or the user's own repositories without one
+func fetchRepos(user string) ([]Repo, error) {
+	if activeView != nil {
+		return GetViewRepos(*activeView, user)
+	}
+	return GetRepos(user)
+}
+
+// GetViewRepos fetches the repositories of a view from its source, merging those of every listed owner;
+// a non-empty user replaces the view's owners
+func GetViewRepos(view ViewConfig, user string) ([]Repo, error) {
+	users := view.Users
+	if user != "" {
+		users = []string{user}
+	}
+	if view.Source == ViewSourceSearch {
+		return SearchRepos(buildSearchQuery(view.Query, users))
+	}
+	if len(users) == 0 {
+		users = []string{""}
+	}
+
+	var repos []Repo
+	seen := make(map[string]bool)
+	for _, u := range users {
+		fetch := GetRepos
+		if view.Source == ViewSourceStarred {
+			fetch = GetStarredRepos
+		}
+		fetched, err := fetch(u)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range fetched {
+			if !seen[repo.FullName()] {
+				seen[repo.FullName()] = true
+				repos = append(repos, repo)
+			}
+		}
+	}
+	return repos, nil
+}
+
+// buildSearchQuery narrows a GitHub search query to the given owners
+func buildSearchQuery(query string, users []string) string {
+	var parts []string
+	if query != "" {
+		parts = append(parts, query)
+	}
+	for _, user := range users {
+		parts = append(parts, "user:"+user)
+	}
+	return strings.Join(parts, " ")
+}
+
+// LoadCurrentView returns the name of the view shown in the picker
+func LoadCurrentView() (string, error) {
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(filepath.Join(cacheDir, viewStateFile))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// SaveCurrentView records the name of the view shown in the picker
+func SaveCurrentView(name string) error {
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		return err
+	}
+	return atomicWriteFile(filepath.Join(cacheDir, viewStateFile), []byte(name+"\n"))
+}
+
+// CycleView switches the picker to the next view and returns its name
+func CycleView() (string, error) {
+	current, _ := LoadCurrentView()
+	next := NextView(current)
+	if err := SaveCurrentView(next); err != nil {
+		return "", fmt.Errorf("failed to save current view: %w", err)
+	}
+	return next, nil
+}
+
+// syncPickerView switches to the view the picker was showing when it closed
+func syncPickerView() {
+	if current, err := LoadCurrentView(); err == nil {
+		_ = ApplyView(current, func(flag string) bool { return explicitFlags[flag] })
+	}
+}
+
+// ViewHeader returns the picker header naming the current view and its key bindings
+func ViewHeader(name string) string {
+	if name == "" {
+		name = "none"
+	}
+	return fmt.Sprintf("View: %s | Ctrl+v next view | Ctrl+r refresh", name)
+}
+
+// PrintViews writes every configured view with a summary of its settings
+func PrintViews(w io.Writer) {
+	names := ViewNames()
+	if len(names) == 0 {
+		fmt.Fprintln(w, "No views configured.")
+		return
+	}
+	for _, name := range names {
+		fmt.Fprintf(w, "%s: %s\n", name, describeView(config.Views[name]))
+	}
+}
+
+// describeView summarises where a view's repositories come from and how they are filtered and sorted
+func describeView(view ViewConfig) string {
+	source := view.Source
+	if source == "" {
+		source = ViewSourceOwned
+	}
+	parts := []string{source}
+	if view.Query != "" {
+		parts = append(parts, fmt.Sprintf("query %q", view.Query))
+	}
+	if len(view.Users) > 0 {
+		parts = append(parts, "users "+strings.Join(view.Users, ", "))
+	}
+	for _, setting := range [][2]string{{"type", view.Type}, {"language", view.Language}, {"filter", view.Filter}, {"sort", view.Sort}} {
+		if setting[1] != "" {
+			parts = append(parts, fmt.Sprintf("%s %q", setting[0], setting[1]))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// validateViews checks the source, owners, filters and sort keys of every view
+func validateViews(views map[string]ViewConfig) error {
+	for name, view := range views {
+		if strings.TrimSpace(name) == "" || strings.ContainsAny(name, " \t\n") {
+			return fmt.Errorf("invalid view name %q: must be a single word", name)
+		}
+		switch view.Source {
+		case "", ViewSourceOwned, ViewSourceStarred:
+		case ViewSourceSearch:
+			if view.Query == "" && len(view.Users) == 0 {
+				return fmt.Errorf("views.%s: search views need a query or users", name)
+			}
+		default:
+			return fmt.Errorf("invalid views.%s.source: %q (supported: %s)", name, view.Source, strings.Join(viewSources, ", "))
+		}
+		for _, user := range view.Users {
+			if err := ValidateUsername(user); err != nil {
+				return fmt.Errorf("invalid views.%s.users entry %q: %w", name, user, err)
+			}
+		}
+		if err := validateViewSettings(view); err != nil {
+			return fmt.Errorf("invalid views.%s.%w", name, err)
+		}
+	}
+	return nil
+}
+
+// validateViewSettings checks the type, filter and sort settings of a view
+func validateViewSettings(view ViewConfig) error {
+	if _, err := ParseRepoTypes(view.Type); err != nil {
+		return fmt.Errorf("type: %w", err)
+	}
+	if _, err := ParseFilter(view.Filter); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	if _, err := ParseSortKeys(view.Sort); err != nil {
+		return fmt.Errorf("sort: %w", err)
+	}
+	return nil
+}
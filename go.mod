@@ -4,6 +4,8 @@ go 1.24.4
 
 require (
 	github.com/spf13/cobra v1.9.1
+	golang.org/x/sys v0.37.0
+	golang.org/x/term v0.36.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 